@@ -8,55 +8,219 @@ package main
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 import (
+	"context"
+	"errors"
 	"fmt"
 	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/lifecycle"
 	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/tracing"
 	"interview-tracker/internal/routes"
+	"io"
+	"io/fs"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"syscall"
 	"time"
+	// card time zones must resolve even in images without /usr/share/zoneinfo
+	_ "time/tzdata"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.elastic.co/apm/module/apmgin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// CORSAllow only allows the configured origins; requests from other origins get no CORS headers.
 func CORSAllow() gin.HandlerFunc {
-	cfg := cors.Config{
-		AllowMethods:     []string{"*"},
-		AllowHeaders:     []string{"*"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	c := config.EnvConfig.CORS
+	if len(c.AllowOrigins) == 0 {
+		logs.Logger.Printf("cors| no allowed origins configured, cross-origin requests are blocked")
+		return func(ctx *gin.Context) { ctx.Next() }
 	}
-
-	cfg.AllowAllOrigins = true
-	return cors.New(cfg)
+	return cors.New(cors.Config{
+		AllowOrigins:     c.AllowOrigins,
+		AllowMethods:     c.AllowMethods,
+		AllowHeaders:     c.AllowHeaders,
+		ExposeHeaders:    c.ExposeHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge,
+	})
 }
 
 func main() {
 	gin.SetMode(gin.ReleaseMode) // if not set releaseMode will be used debug mode
 
-	if err := godotenv.Load(); err != nil {
-		log.Fatalf("Error loading .env file")
+	// .env is optional; containers usually inject env directly
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("config| error loading .env file: %v", err)
+	}
+	if err := config.LoadConfig(os.Args[1:]); err != nil {
+		log.Fatalf("config| %v", err)
 	}
-	config.LoadConfig()
-	logs.LoggerConfig()
+	lc := config.EnvConfig.Log
+	if err := logs.LoggerConfig(config.EnvConfig.GlobalEndpoint, lc.Level, lc.Format); err != nil {
+		log.Fatalf("config| %v", err)
+	}
+	logs.Logger.Printf("config| %s", config.EnvConfig.Redacted())
+	i18n.SetDefault(config.EnvConfig.DefaultLanguage)
+	shutdownTracing := initTracing()
 	config.ConnectDB()
 	router := gin.New()
+	// let c (the gin.Context) be passed as context.Context and still carry the request span
+	router.ContextWithFallback = true
+	config.TrustedProxyConfig(router)
 	router.Use(
-		gin.LoggerWithWriter(gin.DefaultWriter, "/interview-tracker/health"),
-		gin.Recovery(),
+		otelgin.Middleware(config.EnvConfig.Tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+			return !strings.HasPrefix(r.URL.Path, "/interview-tracker/health")
+		})),
+		tracing.GinMiddleware(),
+		// Accept-Language -> request language for error and validation messages
+		i18n.GinMiddleware(),
+		// outside Recovery so a panic is still logged as a 500 response
+		config.RequestLogMiddleware(config.EnvConfig.Log),
+		// renders every error recorded with middleware.Fail as problem+json
+		middleware.ErrorHandler(),
+		gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+			logs.Ctx(c).WithField("stack", string(debug.Stack())).Errorf("panic: %v", err)
+			middleware.Fail(c, errs.Internal())
+		}),
 		apmgin.Middleware(router),
+		metrics.GinMiddleware(),
 	)
-
-	router.Use(config.LogStartTimeMiddleware())
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(middleware.NoRoute)
+	router.NoMethod(middleware.NoMethod)
 
 	config.SwaggerConfig(router)
 	config.NewRedis()
 
+	bg := lifecycle.New()
+
 	router.Use(CORSAllow())
-	routes.Listen(router)
-	router.Run(fmt.Sprintf(":%s", os.Getenv("HTTP_PORT")))
+	routes.Listen(bg, router)
+	routes.Jobs(bg)
+
+	sc := config.EnvConfig.Server
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%s", config.EnvConfig.HttpPort),
+		Handler:           router,
+		ReadTimeout:       sc.ReadTimeout,
+		ReadHeaderTimeout: sc.ReadHeaderTimeout,
+		WriteTimeout:      sc.WriteTimeout,
+		IdleTimeout:       sc.IdleTimeout,
+	}
+	// event streams never finish on their own; end them when shutdown starts
+	srv.RegisterOnShutdown(bg.Drain)
+	metricsSrv := newMetricsServer(config.EnvConfig.MetricsPort, sc)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logs.Logger.Printf("http| listening on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logs.Logger.Printf("http| server error: %v", err)
+			stop()
+		}
+	}()
+	if metricsSrv != nil {
+		go func() {
+			logs.Logger.Printf("metrics| listening on %s", metricsSrv.Addr)
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logs.Logger.Printf("metrics| server error: %v", err)
+				stop()
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	shutdown(srv, metricsSrv, bg, shutdownTracing, sc.ShutdownTimeout)
+}
+
+// newMetricsServer serves /metrics on its own port, so the public listener (and whatever
+// is exposed through it) never reveals route names, traffic or login counts. Expose
+// METRICS_PORT only to the Prometheus network. Returns nil when METRICS_PORT is empty.
+func newMetricsServer(port string, sc config.ServerConfig) *http.Server {
+	if port == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", port),
+		Handler:           mux,
+		ReadHeaderTimeout: sc.ReadHeaderTimeout,
+		WriteTimeout:      sc.WriteTimeout,
+		IdleTimeout:       sc.IdleTimeout,
+	}
+}
+
+// initTracing installs the OTLP tracer provider when TRACING_ENABLED is set.
+// Without it otel keeps its no-op provider, so instrumentation costs almost nothing.
+func initTracing() func(context.Context) error {
+	tc := config.EnvConfig.Tracing
+	if !tc.Enabled {
+		return func(context.Context) error { return nil }
+	}
+	shutdown, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName: tc.ServiceName,
+		Environment: config.EnvConfig.AppEnv,
+		Endpoint:    tc.Endpoint,
+		Insecure:    tc.Insecure,
+		SampleRatio: tc.SampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("tracing| %v", err)
+	}
+	logs.Logger.Printf("tracing| exporting to %s (sample ratio %.2f)", tc.Endpoint, tc.SampleRatio)
+	return shutdown
+}
+
+// shutdown drains in-flight requests first, then stops background goroutines,
+// then closes the Redis client and the DB pool they depend on and flushes traces.
+func shutdown(srv, metricsSrv *http.Server, bg *lifecycle.Group, shutdownTracing func(context.Context) error, timeout time.Duration) {
+	logs.Logger.Printf("shutdown| signal received, draining connections (timeout %s)", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logs.Logger.Printf("shutdown| http: %v", err)
+	}
+	// keep metrics up while draining so the last scrape still sees the requests
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(ctx); err != nil {
+			logs.Logger.Printf("shutdown| metrics: %v", err)
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := bg.Stop(time.Until(deadline)); err != nil {
+			logs.Logger.Printf("shutdown| background: %v", err)
+		}
+	}
+	if err := config.CloseRedis(); err != nil {
+		logs.Logger.Printf("shutdown| redis: %v", err)
+	}
+	if err := config.CloseDB(); err != nil {
+		logs.Logger.Printf("shutdown| database: %v", err)
+	}
+	// flush spans last so the ones from draining requests are exported too
+	if err := shutdownTracing(ctx); err != nil {
+		logs.Logger.Printf("shutdown| tracing: %v", err)
+	}
+	logs.Logger.Printf("shutdown| complete")
 }
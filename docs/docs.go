@@ -15,141 +15,215 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/interview-tracker/authen/cards": {
+        "/interview-tracker/attachments/download": {
+            "get": {
+                "description": "Streams the file for a token from the download URL endpoint. No other authentication.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "download token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/board": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Every status column with its total and its first cards in board order (rank).\nLoad the rest of a column with GET /cards?status=...\u0026sort=rank\u0026page=2\u0026page_size={limit}.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "cards"
                 ],
-                "summary": "List cards",
+                "summary": "Kanban board",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
+                        "default": 20,
+                        "description": "cards per column",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "todo",
-                            "in_progress",
-                            "done"
-                        ],
                         "type": "string",
-                        "default": "todo",
-                        "description": "Filter status",
-                        "name": "status",
-                        "in": "query"
+                        "default": "en",
+                        "description": "th or en; without it the server's DEFAULT_LANGUAGE",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "columns: []card_models.BoardColumn",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/interview-tracker/authen/board/events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Server-Sent Events with card, comment and attachment changes (comment events need comment_view, attachment events attachment_view).\nEach event has id (send it back as Last-Event-ID to resume), event (the type, e.g. card.status_changed) and data (the event JSON).\nAn event named reset means events were missed: reload the board. EventSource cannot send headers, so access_token may be passed as a query parameter.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "cards"
+                    "board"
                 ],
-                "summary": "Create card",
+                "summary": "Board event stream",
                 "parameters": [
                     {
-                        "description": "card create JSON",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/card_models.CreateCardReq"
-                        }
+                        "type": "string",
+                        "description": "resume after this event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "same as Last-Event-ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "access token, for clients that cannot set Authorization",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "event stream",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/authen/cards/comments/{commentId}": {
-            "patch": {
+        "/interview-tracker/authen/calendar": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Interviews overlapping [from, to) for an interviewer (user_id) or a position.\nWithout either filter the caller's own interviews are returned. The range may be at most 93 days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "cards"
                 ],
-                "summary": "Update comment",
+                "summary": "Interview calendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "commentId",
-                        "name": "commentId",
-                        "in": "path",
+                        "default": "2030-01-01T00:00:00+07:00",
+                        "description": "range start (RFC 3339)",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "patch",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/card_models.UpdateCommentReq"
-                        }
+                        "type": "string",
+                        "default": "2030-01-08T00:00:00+07:00",
+                        "description": "range end, exclusive (RFC 3339)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "interviewer id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "position, case-insensitive",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for the returned times (default: each card's own)",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "items: []card_models.CalendarEvent",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
                     }
                 }
             }
         },
-        "/interview-tracker/authen/cards/{id}": {
+        "/interview-tracker/authen/calendar/feeds": {
             "get": {
                 "security": [
                     {
@@ -160,34 +234,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
-                ],
-                "summary": "Get card detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "calendar"
                 ],
+                "summary": "List my calendar feeds",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.CalendarFeedToken"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Issue a secret URL that Outlook or Google Calendar can subscribe to. The URL is only returned once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -195,40 +269,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "calendar"
                 ],
-                "summary": "Update card (partial)",
+                "summary": "Create calendar feed URL",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "patch",
+                        "description": "feed JSON",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/card_models.UpdateCardReq"
+                            "$ref": "#/definitions/calendar_models.CreateFeedTokenReq"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/calendar_models.CreateFeedTokenResp"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/authen/cards/{id}/comments": {
-            "get": {
+        "/interview-tracker/authen/calendar/feeds/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -238,107 +310,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "calendar"
                 ],
-                "summary": "List comments",
+                "summary": "Revoke calendar feed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "card id",
+                        "description": "feed id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "size",
-                        "name": "page_size",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/interview-tracker/authen/card-statuses": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "name and description follow Accept-Language (th or en)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "cards"
                 ],
-                "summary": "Add comment",
+                "summary": "list card statuses",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "comment",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/card_models.AddCommentReq"
-                        }
+                        "default": "en",
+                        "description": "th or en; without it the server's DEFAULT_LANGUAGE",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.CardStatus"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/authen/cards/{id}/history": {
+        "/interview-tracker/authen/cards": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "description is Markdown; description_html is the sanitized HTML and description_excerpt plain text (limits.excerpt_length)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "cards"
                 ],
-                "summary": "list history logs",
+                "summary": "List cards",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "description": "page",
@@ -347,9 +408,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "size",
+                        "description": "page size",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "todo",
+                            "in_progress",
+                            "done"
+                        ],
+                        "type": "string",
+                        "default": "todo",
+                        "description": "Filter status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled_at",
+                            "rank"
+                        ],
+                        "type": "string",
+                        "default": "scheduled_at",
+                        "description": "scheduled_at, or rank for board order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -359,11 +443,15 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
                     }
                 }
-            }
-        },
-        "/interview-tracker/authen/cards/{id}/keep": {
+            },
             "post": {
                 "security": [
                     {
@@ -379,14 +467,16 @@ const docTemplate = `{
                 "tags": [
                     "cards"
                 ],
-                "summary": "จัดเก็บ",
+                "summary": "Create card",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "card id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "card create JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/card_models.CreateCardReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -396,43 +486,44 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "409": {
+                        "description": "an interviewer is double-booked; conflicts lists the overlapping cards",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
                     }
                 }
             }
         },
-        "/interview-tracker/authen/cards/{id}/status": {
-            "patch": {
+        "/interview-tracker/authen/cards/attachments/{attachmentId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "The uploader (attachment_add) may delete their own attachment; attachment_delete allows deleting anyone's.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "attachments"
                 ],
-                "summary": "Change status",
+                "summary": "Delete attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "card id",
-                        "name": "id",
+                        "description": "attachment id",
+                        "name": "attachmentId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "new status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/card_models.UpdateCardStatusReq"
-                        }
                     }
                 ],
                 "responses": {
@@ -442,147 +533,203 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
                     }
                 }
             }
         },
-        "/interview-tracker/health": {
+        "/interview-tracker/authen/cards/attachments/{attachmentId}/url": {
             "get": {
-                "description": "Returns success if the service is healthy",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A short-lived link (ATTACHMENT_URL_TTL) that downloads the file without further authentication:\na presigned URL of the object store, or the service's download endpoint with a signed token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "attachments"
+                ],
+                "summary": "Attachment download URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "attachment id",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/attachment_models.DownloadURLResp"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/internal/v1/auth/login": {
-            "post": {
-                "description": "Authenticate user and issue access/refresh tokens (JWT access token has only sub/exp/iat/iss)",
-                "consumes": [
-                    "application/json"
+        "/interview-tracker/authen/cards/comments/{commentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Soft delete: the comment leaves the lists (a thread with replies keeps a placeholder) and can be restored.\nThe author (comment_edit) may delete their own comment; a moderator (comment_delete) anyone's, with a reason.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "comments"
                 ],
-                "summary": "Login",
+                "summary": "Delete comment",
                 "parameters": [
                     {
-                        "description": "login JSON",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth_models.LoginReq"
-                        }
+                        "type": "string",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "required when deleting someone else's comment",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth_models.TokenResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/interview-tracker/internal/v1/auth/logout": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Revoke current session using access token (refID is taken from JWT ` + "`" + `sub` + "`" + `)",
+                "description": "The previous content is kept in the edit history and the comment is marked edited. Users mentioned for the first time are notified.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "comments"
                 ],
-                "summary": "Logout",
-                "responses": {
-                    "200": {
-                        "description": "ok",
+                "summary": "Update comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "patch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/card_models.UpdateCommentReq"
                         }
-                    },
-                    "401": {
-                        "description": "unauthorized",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "logout failed",
+                    "default": {
+                        "description": "problem details",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/internal/v1/auth/refresh": {
-            "post": {
-                "description": "Rotate refresh token and issue a new access token",
-                "consumes": [
-                    "application/json"
+        "/interview-tracker/authen/cards/comments/{commentId}/edits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Earlier versions of the comment, newest first; content is the text before that edit.\nThose of a deleted comment are shown to moderators (comment_delete) only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "comments"
                 ],
-                "summary": "Refresh token",
+                "summary": "Comment edit history",
                 "parameters": [
                     {
-                        "description": "refresh JSON",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth_models.RefreshReq"
-                        }
+                        "type": "string",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "items: []entities.CommentEdit",
                         "schema": {
-                            "$ref": "#/definitions/auth_models.TokenResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/internal/v1/users/create": {
+        "/interview-tracker/authen/cards/comments/{commentId}/reactions": {
             "post": {
-                "description": "Create user for",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds the caller's emoji reaction (adding it again changes nothing) and returns the comment's reactions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -590,111 +737,1592 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "comments"
                 ],
-                "summary": "Create user",
+                "summary": "React to a comment",
                 "parameters": [
                     {
-                        "description": "user create JSON",
+                        "type": "string",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "emoji",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user_models.CreateUserRequest"
+                            "$ref": "#/definitions/card_models.ReactionReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Success response",
+                    "200": {
+                        "description": "reactions: []entities.ReactionSummary",
                         "schema": {
-                            "$ref": "#/definitions/user_models.CreateUserResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "default": {
+                        "description": "problem details",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/internal/v1/users/details/{userId}": {
-            "get": {
-                "description": "Get user By ID",
-                "consumes": [
-                    "application/json"
+        "/interview-tracker/authen/cards/comments/{commentId}/reactions/{emoji}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Removes the caller's emoji reaction (URL-encoded in the path) and returns the comment's reactions.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "comments"
                 ],
-                "summary": "Get user By ID",
+                "summary": "Remove a reaction",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "emoji, e.g. %F0%9F%91%8D",
+                        "name": "emoji",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "reactions: []entities.ReactionSummary",
                         "schema": {
-                            "$ref": "#/definitions/entities.User"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "default": {
+                        "description": "problem details",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
             }
         },
-        "/interview-tracker/internal/v1/users/role-list": {
-            "get": {
-                "description": "Get role",
-                "consumes": [
-                    "application/json"
-                ],
+        "/interview-tracker/authen/cards/comments/{commentId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undoes a delete. A moderator (comment_delete) may restore any comment; the author only one they deleted themselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Restore comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "commentId",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "comment: entities.CardComment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get card detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Update card (partial)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "patch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/card_models.UpdateCardReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "the card is archived, or an interviewer is double-booked; conflicts lists the overlapping cards",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The card's attachments, newest first, with the upload limits.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "List attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "items: []entities.CardAttachment, limits: attachment_models.UploadLimits",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "multipart/form-data with the file in the \"file\" field; it is streamed to storage, not buffered.\nThe type is detected from the content and must be one of the allowed types; the size limit and the types are in limits of the list response.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "the file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "attachment: entities.CardAttachment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "file too large",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "type not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "content is Markdown, content_html the sanitized HTML and excerpt plain text.\nflat: every comment, newest first. threaded: top-level comments newest first, each with its replies (oldest first);\npage, page_size and total then count top-level comments.\nDeleted comments are left out; a deleted comment with replies heads its thread as a placeholder (content empty, is_active false).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "flat",
+                            "threaded"
+                        ],
+                        "type": "string",
+                        "default": "flat",
+                        "description": "flat or threaded",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "list deleted comments in full (comment_delete only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "content is Markdown (CommonMark with GitHub tables, task lists and autolinks; raw HTML is dropped).\nSet parent_id to reply; a reply to a reply joins the same thread.\nMention users with @name (their email before '@') or @email; mentioned users are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Add comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/card_models.AddCommentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "list history logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "One RFC 5545 VEVENT for the card; archived cards come out as CANCELLED",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Download card as iCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "BEGIN:VCALENDAR ...",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "card not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/keep": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archive the card: it leaves lists and the calendar, and subscribed calendars show it as cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "จัดเก็บ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the card's status and its position in the column at once.\nafter_id puts it right below that card, before_id right above it; with neither it goes to the bottom of the column.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Move card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "target column and position",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/card_models.MoveCardReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "the card is archived, or the anchor card is not in the target column",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/authen/cards/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Change status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/card_models.UpdateCardStatusReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "the card is archived",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/calendar/feed.ics": {
+            "get": {
+                "description": "Subscription feed of the token owner's interviews (a week back to a year ahead). No other authentication.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "BEGIN:VCALENDAR ...",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "unknown or revoked token",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/health": {
+            "get": {
+                "description": "Returns success if the service is healthy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/health/live": {
+            "get": {
+                "description": "Returns 200 while the process is running; does not touch dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/health/ready": {
+            "get": {
+                "description": "Checks database, redis, migration state and JWT keys; 503 when any check fails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.Readiness"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.Readiness"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ApiKey"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a long-lived API key scoped to a subset of the caller's permissions. The raw key is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Issue API key",
+                "parameters": [
+                    {
+                        "description": "api key JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api_key_models.CreateApiKeyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api_key_models.CreateApiKeyResp"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden, or called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "api key id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/auth/login": {
+            "post": {
+                "description": "Authenticate user and issue access/refresh tokens (JWT access token has only sub/exp/iat/iss)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "login JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth_models.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/auth_models.TokenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "too many failed logins for this email from this client IP (login_locked), or rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke current session using access token (refID is taken from JWT ` + "`" + `sub` + "`" + `)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "logout failed",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/auth/refresh": {
+            "post": {
+                "description": "Rotate refresh token and issue a new access token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
+                    {
+                        "description": "refresh JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth_models.RefreshReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/auth_models.TokenResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/auth/sso/callback": {
+            "get": {
+                "description": "Exchange the authorization code and issue access/refresh tokens (same shape as password login)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "SSO callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/auth_models.TokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "sso failed",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "user not provisioned, or account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/auth/sso/login": {
+            "get": {
+                "description": "Redirect to the corporate identity provider (OIDC authorization code + PKCE)",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "SSO login",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "502": {
+                        "description": "identity provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/users/create": {
+            "post": {
+                "description": "Create user for",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "user create JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user_models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success response",
+                        "schema": {
+                            "$ref": "#/definitions/user_models.CreateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/users/details/{userId}": {
+            "get": {
+                "description": "Get user By ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/users/permission-list": {
+            "get": {
+                "description": "List active permissions; name and description follow Accept-Language (th or en)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "en",
+                        "description": "th or en; without it the server's DEFAULT_LANGUAGE",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Permission"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/users/role-list": {
+            "get": {
+                "description": "Get role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get role",
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Role"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Webhook"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to card and comment events. Requests are signed with HMAC-SHA256 using the returned secret, which is only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "webhook JSON",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook_models.CreateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhook_models.CreateWebhookResp"
+                        }
+                    },
+                    "default": {
+                        "description": "problem details",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the webhook and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the URL, description or events, or enable/disable it. Enabling resets the failure count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "patch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook_models.UpdateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The delivery log, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded or failed",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/errs.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/interview-tracker/internal/v1/webhooks/{id}/deliveries/{deliveryId}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue the same event again as a new delivery",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Replay delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivery id",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get role",
                 "responses": {
-                    "200": {
-                        "description": "Successful response",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.Role"
-                            }
+                            "$ref": "#/definitions/entities.WebhookDelivery"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "404": {
+                        "description": "not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errs.Problem"
                         }
                     }
                 }
@@ -702,6 +2330,86 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "api_key_models.CreateApiKeyReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "example": "ats-sync"
+                },
+                "permissions": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "card_view",
+                        "card_add"
+                    ]
+                }
+            }
+        },
+        "api_key_models.CreateApiKeyResp": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b2a8f4e5-9e3b-4c2b-9e0f-7b2c1a6d1f33"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "itk_3f9a1c2e_Qm9wN2..."
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ats-sync"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "card_view",
+                        "card_add"
+                    ]
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "itk_3f9a1c2e"
+                }
+            }
+        },
+        "attachment_models.DownloadURLResp": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:05:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://tracker.example.com/interview-tracker/attachments/download?token=eyJhbGciOi..."
+                }
+            }
+        },
         "auth_models.LoginReq": {
             "type": "object",
             "required": [
@@ -711,10 +2419,12 @@ const docTemplate = `{
             "properties": {
                 "email": {
                     "type": "string",
+                    "maxLength": 254,
                     "example": "example@example.com"
                 },
                 "password": {
                     "type": "string",
+                    "maxLength": 72,
                     "example": "P@ssw0rd"
                 }
             }
@@ -726,7 +2436,8 @@ const docTemplate = `{
             ],
             "properties": {
                 "refresh_token": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 512
                 }
             }
         },
@@ -747,6 +2458,64 @@ const docTemplate = `{
                 }
             }
         },
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "uptime": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "calendar_models.CreateFeedTokenReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 120,
+                    "example": "Outlook ที่ทำงาน"
+                }
+            }
+        },
+        "calendar_models.CreateFeedTokenResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "b2a8f4e5-9e3b-4c2b-9e0f-7b2c1a6d1f33"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Outlook ที่ทำงาน"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "itc_3f9a1c2e"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "itc_3f9a1c2e_Qm9wN2..."
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://tracker.example.com/interview-tracker/calendar/feed.ics?token=itc_3f9a1c2e_Qm9wN2..."
+                }
+            }
+        },
         "card_models.AddCommentReq": {
             "type": "object",
             "required": [
@@ -755,7 +2524,13 @@ const docTemplate = `{
             "properties": {
                 "content": {
                     "type": "string",
-                    "example": "ควรปรับปรุง portfolio ให้ละเอียดขึ้น"
+                    "maxLength": 5000,
+                    "example": "@somchai ควรปรับปรุง **portfolio** ให้ละเอียดขึ้น"
+                },
+                "parent_id": {
+                    "description": "reply to this comment; replies to a reply join its thread",
+                    "type": "string",
+                    "example": ""
                 }
             }
         },
@@ -768,61 +2543,317 @@ const docTemplate = `{
             "properties": {
                 "description": {
                     "type": "string",
+                    "maxLength": 5000,
                     "example": "สัมภาษณ์ตำแหน่ง Backend Developer"
                 },
-                "scheduled_at": {
-                    "type": "string",
-                    "example": "2023-01-01T10:00:00Z"
+                "ends_at": {
+                    "description": "default scheduled_at + 1h",
+                    "type": "string",
+                    "example": "2030-01-01T11:00:00+07:00"
+                },
+                "interviewer_ids": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"
+                    ]
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "ห้องประชุม 3A"
+                },
+                "meeting_url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://meet.example.com/abc-defg-hij"
+                },
+                "position": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Backend Developer"
+                },
+                "scheduled_at": {
+                    "description": "start",
+                    "type": "string",
+                    "example": "2030-01-01T10:00:00+07:00"
+                },
+                "time_zone": {
+                    "description": "default Asia/Bangkok",
+                    "type": "string",
+                    "example": "Asia/Bangkok"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "นัดสัมภาษณ์งาน 1"
+                }
+            }
+        },
+        "card_models.MoveCardReq": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "after_id": {
+                    "type": "string",
+                    "example": "5b0f1f3e-8c1a-4a57-9d2e-3f4a5b6c7d8e"
+                },
+                "before_id": {
+                    "type": "string",
+                    "example": ""
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
+        "card_models.ReactionReq": {
+            "type": "object",
+            "required": [
+                "emoji"
+            ],
+            "properties": {
+                "emoji": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "👍"
+                }
+            }
+        },
+        "card_models.UpdateCardReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "สัมภาษณ์ตำแหน่ง Fullstack Developer"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2030-01-02T16:00:00+07:00"
+                },
+                "interviewer_ids": {
+                    "description": "replaces the list",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"
+                    ]
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Online"
+                },
+                "meeting_url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://meet.example.com/abc-defg-hij"
+                },
+                "position": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Fullstack Developer"
+                },
+                "scheduled_at": {
+                    "description": "without ends_at the end moves by the same amount",
+                    "type": "string",
+                    "example": "2030-01-02T15:00:00+07:00"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Bangkok"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1,
+                    "example": "นัดสัมภาษณ์งาน 2"
+                }
+            }
+        },
+        "card_models.UpdateCardStatusReq": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ],
+                    "example": "in_progress"
+                }
+            }
+        },
+        "card_models.UpdateCommentReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "ใช้ได้"
+                }
+            }
+        },
+        "entities.ApiKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CalendarFeedToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "นัดสัมภาษณ์งาน 1"
+                "revoked_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "card_models.UpdateCardReq": {
+        "entities.CardStatus": {
             "type": "object",
             "properties": {
                 "description": {
-                    "type": "string",
-                    "example": "สัมภาษณ์ตำแหน่ง Fullstack Developer"
+                    "type": "string"
                 },
-                "scheduled_at": {
-                    "type": "string",
-                    "example": "2023-01-02T15:00:00Z"
+                "description_en": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "นัดสัมภาษณ์งาน 2"
-                }
-            }
-        },
-        "card_models.UpdateCardStatusReq": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "todo",
-                        "in_progress",
-                        "done"
-                    ],
-                    "example": "in_progress"
+                "description_th": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_th": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "string"
                 }
             }
         },
-        "card_models.UpdateCommentReq": {
+        "entities.Permission": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "ใช้ได้"
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_en": {
+                    "type": "string"
+                },
+                "description_th": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_th": {
+                    "description": "translations; Name/Description are replaced by Localize for the request language",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -881,6 +2912,165 @@ const docTemplate = `{
                 }
             }
         },
+        "entities.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "disabled_reason": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failure_count": {
+                    "description": "consecutive failed attempts",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "replayed_from": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "errs.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "conflicts": {},
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "retry_after": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.Readiness": {
+            "type": "object",
+            "properties": {
+                "build": {
+                    "$ref": "#/definitions/buildinfo.Info"
+                },
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/usecases.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "user_models.CreateUserRequest": {
             "type": "object",
             "required": [
@@ -892,6 +3082,7 @@ const docTemplate = `{
             "properties": {
                 "email": {
                     "type": "string",
+                    "maxLength": 254,
                     "example": "example@example.com"
                 },
                 "is_active": {
@@ -904,6 +3095,8 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
                     "example": "P@ssw0rd"
                 },
                 "role_id": {
@@ -931,9 +3124,124 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "webhook_models.CreateWebhookReq": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Slack bot"
+                },
+                "events": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "card.created",
+                        "card.status_changed"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://hooks.example.com/interview-tracker"
+                }
+            }
+        },
+        "webhook_models.CreateWebhookResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "disabled_reason": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failure_count": {
+                    "description": "consecutive failed attempts",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_Qm9wN2..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook_models.UpdateWebhookReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Slack bot"
+                },
+                "events": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "card.created"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://hooks.example.com/interview-tracker"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "type": "apiKey",
             "name": "Authorization",
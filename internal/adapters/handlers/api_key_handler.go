@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/models/api_key_models"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeyHandler struct{ uc *usecases.ApiKeyUsecase }
+
+func NewApiKeyHandler(uc *usecases.ApiKeyUsecase) *ApiKeyHandler { return &ApiKeyHandler{uc} }
+
+// @Summary      Issue API key
+// @Description  Issue a long-lived API key scoped to a subset of the caller's permissions. The raw key is only returned once.
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  api_key_models.CreateApiKeyReq  true  "api key JSON"
+// @Success      201  {object}  api_key_models.CreateApiKeyResp
+// @Failure      403  {object}  errs.Problem  "forbidden, or called with an API key"
+// @Router       /interview-tracker/internal/v1/api-keys [post]
+func (h *ApiKeyHandler) Create(c *gin.Context) {
+	logs.Ctx(c).Infof("[api-key] create start...")
+	if !requireUserSession(c) {
+		return
+	}
+	var req api_key_models.CreateApiKeyReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	session := middleware.GetSession(c)
+	res, err := h.uc.Issue(c, session.UserID, session.Perms, req)
+	if err != nil {
+		logs.Ctx(c).Errorf("[api-key] create failed: %v", err)
+		middleware.Fail(c, err)
+		return
+	}
+	logs.Ctx(c).Infof("[api-key] create success: %s", res.Prefix)
+	c.JSON(http.StatusCreated, res)
+}
+
+// @Summary      List API keys
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  entities.ApiKey
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/internal/v1/api-keys [get]
+func (h *ApiKeyHandler) List(c *gin.Context) {
+	items, err := h.uc.List(c)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// @Summary      Revoke API key
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "api key id"
+// @Success      200  {object}  map[string]string  "ok"
+// @Failure      403  {object}  errs.Problem  "called with an API key"
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/api-keys/{id} [delete]
+func (h *ApiKeyHandler) Revoke(c *gin.Context) {
+	if !requireUserSession(c) {
+		return
+	}
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	session := middleware.GetSession(c)
+	if err := h.uc.Revoke(c, session.UserID, id); err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// requireUserSession rejects callers authenticated by an API key: a leaked key could
+// otherwise issue itself a replacement that never expires, or revoke other keys
+func requireUserSession(c *gin.Context) bool {
+	if c.GetString("apiKeyID") != "" || middleware.GetSession(c).Role == "api_key" {
+		logs.Ctx(c).Warnf("[api-key] key management called with api key %s", c.GetString("apiKeyID"))
+		middleware.Fail(c, errs.Forbidden("api keys cannot manage api keys").WithCode("session_required"))
+		return false
+	}
+	return true
+}
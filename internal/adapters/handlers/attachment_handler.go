@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// room above the file size limit for the multipart boundaries and part headers
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	uc *usecases.AttachmentUsecase
+	// replaces the server read/write timeouts for one upload or download
+	transferTimeout time.Duration
+}
+
+func NewAttachmentHandler(uc *usecases.AttachmentUsecase, transferTimeout time.Duration) *AttachmentHandler {
+	return &AttachmentHandler{uc: uc, transferTimeout: transferTimeout}
+}
+
+// @Summary      Upload attachment
+// @Description  multipart/form-data with the file in the "file" field; it is streamed to storage, not buffered.
+// @Description  The type is detected from the content and must be one of the allowed types; the size limit and the types are in limits of the list response.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string  true  "card id"
+// @Param        file  formData  file    true  "the file"
+// @Success      201  {object}  map[string]any  "attachment: entities.CardAttachment"
+// @Failure      413  {object}  errs.Problem  "file too large"
+// @Failure      415  {object}  errs.Problem  "type not allowed"
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/cards/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	h.extendDeadlines(c)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.uc.Limits().MaxBytes+multipartOverhead)
+	part, ok := filePart(c)
+	if !ok {
+		return
+	}
+	defer part.Close()
+
+	a, err := h.uc.Upload(c, id, middleware.GetSession(c).UserID, part.FileName(), part)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	logs.Ctx(c).Infof("[attachment] %s uploaded to card %s (%d bytes, %s)", a.ID, id, a.SizeBytes, a.ContentType)
+	c.JSON(http.StatusCreated, gin.H{"attachment": a})
+}
+
+// filePart returns the part holding the "file" field, skipping any other fields before it
+func filePart(c *gin.Context) (*multipart.Part, bool) {
+	missing := func() {
+		msg := i18n.T(i18n.FromContext(c), "validation.required", nil)
+		middleware.Fail(c, errs.Validation("a file is required", map[string]string{"file": msg}))
+	}
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		missing()
+		return nil, false
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			missing()
+			return nil, false
+		}
+		if err != nil {
+			var maxBytes *http.MaxBytesError
+			if errors.As(err, &maxBytes) {
+				middleware.Fail(c, usecases.ErrAttachmentTooLarge)
+				return nil, false
+			}
+			middleware.Fail(c, errs.BadRequest("invalid multipart body").WithCode("invalid_multipart"))
+			return nil, false
+		}
+		if part.FormName() == "file" && part.FileName() != "" {
+			return part, true
+		}
+		part.Close()
+	}
+}
+
+// @Summary      List attachments
+// @Description  The card's attachments, newest first, with the upload limits.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "card id"
+// @Success      200  {object}  map[string]any  "items: []entities.CardAttachment, limits: attachment_models.UploadLimits"
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/cards/{id}/attachments [get]
+func (h *AttachmentHandler) List(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	items, err := h.uc.List(c, id)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items, "limits": h.uc.Limits()})
+}
+
+// @Summary      Attachment download URL
+// @Description  A short-lived link (ATTACHMENT_URL_TTL) that downloads the file without further authentication:
+// @Description  a presigned URL of the object store, or the service's download endpoint with a signed token.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        attachmentId  path  string  true  "attachment id"
+// @Success      200  {object}  attachment_models.DownloadURLResp
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/cards/attachments/{attachmentId}/url [get]
+func (h *AttachmentHandler) DownloadURL(c *gin.Context) {
+	id, ok := paramUUID(c, "attachmentId")
+	if !ok {
+		return
+	}
+	res, err := h.uc.DownloadURL(c, id)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary      Download attachment
+// @Description  Streams the file for a token from the download URL endpoint. No other authentication.
+// @Tags         attachments
+// @Produce      application/octet-stream
+// @Param        token  query  string  true  "download token"
+// @Success      200  {file}  file  "the file"
+// @Failure      401  {object}  errs.Problem  "invalid or expired token"
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/attachments/download [get]
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	a, rc, err := h.uc.Open(c, c.Query("token"))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	defer rc.Close()
+	h.extendDeadlines(c)
+
+	// always a download, never rendered in the browser
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Length", strconv.FormatInt(a.SizeBytes, 10))
+	c.Header("Content-Type", a.ContentType)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, rc); err != nil {
+		logs.Ctx(c).Warnf("[attachment] download %s interrupted: %v", a.ID, err)
+	}
+}
+
+// extendDeadlines gives a transfer transferTimeout from now, whatever the server timeouts are
+func (h *AttachmentHandler) extendDeadlines(c *gin.Context) {
+	rc := http.NewResponseController(c.Writer)
+	deadline := time.Now().Add(h.transferTimeout)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		logs.Ctx(c).Warnf("[attachment] cannot extend read deadline: %v", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		logs.Ctx(c).Warnf("[attachment] cannot extend write deadline: %v", err)
+	}
+}
+
+// @Summary      Delete attachment
+// @Description  The uploader (attachment_add) may delete their own attachment; attachment_delete allows deleting anyone's.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        attachmentId  path  string  true  "attachment id"
+// @Success      200  {object}  map[string]any
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/cards/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	id, ok := paramUUID(c, "attachmentId")
+	if !ok {
+		return
+	}
+	if err := h.uc.Delete(c, id, middleware.GetSession(c).UserID, middleware.HasPerm(c, "attachment_delete")); err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -3,8 +3,11 @@ package handlers
 import (
 	"net/http"
 
+	"interview-tracker/internal/middleware"
 	"interview-tracker/internal/models/auth_models"
+	"interview-tracker/internal/pkg/errs"
 	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
 	"interview-tracker/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -21,23 +24,27 @@ func NewAuthHandler(a usecases.AuthUsecase) *AuthHandler { return &AuthHandler{a
 // @Produce      json
 // @Param        request  body  auth_models.LoginReq  true  "login JSON"
 // @Success      200      {object} auth_models.TokenResponse  "Success"
+// @Failure      429      {object} errs.Problem  "too many failed logins for this email from this client IP (login_locked), or rate limited"
+// @Failure default {object} errs.Problem "problem details"
 // @Router       /interview-tracker/internal/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
-	logs.Logger.Printf("[auth] login start...")
+	logs.Ctx(c).Infof("[auth] login start...")
 	var req auth_models.LoginReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logs.Logger.Printf("[auth] login bind error: %v", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &req) {
+		logs.Ctx(c).Warnf("[auth] login invalid request: %v", c.Errors.Last())
 		return
 	}
-	access, refresh, refID, err := h.auth.Login(c, req.Email, req.Password)
+	clientIP := middleware.ClientIP(c)
+	access, refresh, refID, err := h.auth.Login(c, req.Email, req.Password, clientIP)
 	if err != nil {
-		logs.Logger.Printf("[auth] login error: %v", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+		logs.Ctx(c).Warnf("[auth] login error: %v client_ip: %s", err, clientIP)
+		metrics.Logins.WithLabelValues("password", "failure").Inc()
+		middleware.Fail(c, err)
 		return
 	}
 
-	logs.Logger.Printf("[auth] login success...")
+	logs.Ctx(c).Infof("[auth] login success...")
+	metrics.Logins.WithLabelValues("password", "success").Inc()
 	c.JSON(http.StatusOK, gin.H{"access_token": access, "refresh_token": refresh, "ref_id": refID})
 }
 
@@ -48,21 +55,21 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce      json
 // @Param        request  body  auth_models.RefreshReq  true  "refresh JSON"
 // @Success      200      {object} auth_models.TokenResponse  "Success"
+// @Failure default {object} errs.Problem "problem details"
 // @Router       /interview-tracker/internal/v1/auth/refresh [post]
 func (h *AuthHandler) Refresh(c *gin.Context) {
-	logs.Logger.Printf("[auth] refresh start...")
+	logs.Ctx(c).Infof("[auth] refresh start...")
 	var req auth_models.RefreshReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 	newAccess, newRefresh, refID, err := h.auth.Refresh(c, req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		middleware.Fail(c, err)
 		return
 	}
 
-	logs.Logger.Printf("[auth] refresh success...")
+	logs.Ctx(c).Infof("[auth] refresh success...")
 	c.JSON(http.StatusOK, gin.H{"access_token": newAccess, "refresh_token": newRefresh, "ref_id": refID})
 }
 
@@ -72,17 +79,17 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 // @Security     BearerAuth
 // @Produce      json
 // @Success      200  {object}  map[string]string  "ok"
-// @Failure      401  {object}  map[string]string  "unauthorized"
-// @Failure      500  {object}  map[string]string  "logout failed"
+// @Failure      401  {object}  errs.Problem  "unauthorized"
+// @Failure      500  {object}  errs.Problem  "logout failed"
 // @Router       /interview-tracker/internal/v1/auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
 	refID := c.GetString("refID") // set โดย auth middleware หลัง verify JWT
 	if refID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		middleware.Fail(c, errs.Unauthorized("logout requires a user session").WithCode("session_required"))
 		return
 	}
 	if err := h.auth.Logout(c, refID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// Every handler reads its input through these helpers so requests are checked the same way:
+// unknown JSON fields are rejected, strings are trimmed, `binding` tags are validated,
+// and failures are reported as a validation problem with a field -> message map.
+// Each helper returns false after it has already called middleware.Fail.
+
+// maxJSONBody caps JSON request bodies; the largest legitimate ones (card descriptions,
+// comments) are a few KB. File uploads are multipart and have their own limit.
+const maxJSONBody = 1 << 20
+
+func bindJSON(c *gin.Context, req any) bool {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxJSONBody)
+	dec := json.NewDecoder(c.Request.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		middleware.Fail(c, jsonError(err, i18n.FromContext(c)))
+		return false
+	}
+	return validate(c, req)
+}
+
+func bindQuery(c *gin.Context, req any) bool {
+	if err := binding.MapFormWithTag(req, c.Request.URL.Query(), "form"); err != nil {
+		msg := i18n.T(i18n.FromContext(c), "validation.invalid", map[string]string{"param": err.Error()})
+		middleware.Fail(c, errs.Validation("invalid query parameters", map[string]string{"_": msg}))
+		return false
+	}
+	return validate(c, req)
+}
+
+// paramUUID parses a UUID path parameter such as :id
+func paramUUID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		msg := i18n.T(i18n.FromContext(c), "validation.uuid", nil)
+		middleware.Fail(c, errs.Validation("invalid path parameter", map[string]string{name: msg}))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// uuids converts ids already checked by a `uuid` binding tag
+func uuids(ids []string) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, uuid.MustParse(id))
+	}
+	return out
+}
+
+func validate(c *gin.Context, req any) bool {
+	utils.TrimStrings(req)
+	if fields := utils.ValidateRequest(req, i18n.FromContext(c)); fields != nil {
+		middleware.Fail(c, errs.Validation("invalid request", fields))
+		return false
+	}
+	return true
+}
+
+func jsonError(err error, lang string) error {
+	var typeErr *json.UnmarshalTypeError
+	var maxBytes *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytes):
+		return errs.New(http.StatusRequestEntityTooLarge, "body_too_large", "request body is too large")
+	case errors.Is(err, io.EOF):
+		return errs.BadRequest("request body is required").WithCode("body_required")
+	case errors.As(err, &typeErr):
+		msg := i18n.T(lang, "validation.type", map[string]string{"param": typeErr.Type.String()})
+		return errs.Validation("invalid request", map[string]string{typeErr.Field: msg})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return errs.Validation("invalid request", map[string]string{field: i18n.T(lang, "validation.unknown_field", nil)})
+	}
+	return errs.BadRequest("malformed JSON body").WithCode("malformed_json")
+}
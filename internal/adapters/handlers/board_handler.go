@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/realtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// a comment line every heartbeat keeps proxies from closing an idle stream
+	streamHeartbeat = 25 * time.Second
+	// streams end after this long so permissions are checked again when the client reconnects
+	streamMaxAge = time.Hour
+	// how long EventSource waits before reconnecting, sent as the SSE retry field
+	streamRetry = 3 * time.Second
+	// events replayed at most on resume; a client further behind is told to reload
+	streamMaxReplay = 1000
+)
+
+type BoardHandler struct {
+	hub      *realtime.Hub
+	draining <-chan struct{}
+}
+
+func NewBoardHandler(hub *realtime.Hub, draining <-chan struct{}) *BoardHandler {
+	return &BoardHandler{hub: hub, draining: draining}
+}
+
+// @Summary      Board event stream
+// @Description  Server-Sent Events with card, comment and attachment changes (comment events need comment_view, attachment events attachment_view).
+// @Description  Each event has id (send it back as Last-Event-ID to resume), event (the type, e.g. card.status_changed) and data (the event JSON).
+// @Description  An event named reset means events were missed: reload the board. EventSource cannot send headers, so access_token may be passed as a query parameter.
+// @Tags         board
+// @Security     BearerAuth
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header  string  false  "resume after this event id"
+// @Param        last_event_id  query   string  false  "same as Last-Event-ID"
+// @Param        access_token   query   string  false  "access token, for clients that cannot set Authorization"
+// @Success      200  {string}  string  "event stream"
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/board/events [get]
+func (h *BoardHandler) Events(c *gin.Context) {
+	session := middleware.GetSession(c)
+	canComments := slices.Contains(session.Perms, "comment_view")
+	canAttachments := slices.Contains(session.Perms, "attachment_view")
+	visible := func(m realtime.Message) bool {
+		switch {
+		case strings.HasPrefix(m.Type, "comment."):
+			return canComments
+		case strings.HasPrefix(m.Type, "attachment."):
+			return canAttachments
+		}
+		return true
+	}
+
+	// subscribe before reading the backlog so nothing falls between the two
+	sub := h.hub.Subscribe()
+	defer h.hub.Unsubscribe(sub)
+
+	// the server WriteTimeout would otherwise cut the stream
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logs.Ctx(c).Warnf("[board] cannot clear write deadline: %v", err)
+	}
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	last := c.GetHeader("Last-Event-ID")
+	if last == "" {
+		last = c.Query("last_event_id")
+	}
+	if realtime.ValidID(last) {
+		backlog, complete, err := h.hub.Since(c, last, streamMaxReplay)
+		if err != nil {
+			logs.Ctx(c).Errorf("[board] resume from %s failed: %v", last, err)
+		}
+		if err != nil || !complete {
+			fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n")
+		}
+		for _, m := range backlog {
+			if visible(m) {
+				writeEvent(c, m)
+			}
+			last = m.ID
+		}
+	} else {
+		last = ""
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	expire := time.NewTimer(streamMaxAge)
+	defer expire.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.draining:
+			return
+		case <-expire.C:
+			return
+		case <-heartbeat.C:
+			if !middleware.SessionActive(c) {
+				return
+			}
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case m, ok := <-sub.C:
+			if !ok {
+				return // shutting down, or too slow: the client resumes from its last id
+			}
+			if last != "" && realtime.CompareIDs(m.ID, last) <= 0 {
+				continue // already sent from the backlog
+			}
+			last = m.ID
+			if !visible(m) {
+				continue
+			}
+			if err := writeEvent(c, m); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(c *gin.Context, m realtime.Message) error {
+	_, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Type, m.Data)
+	return err
+}
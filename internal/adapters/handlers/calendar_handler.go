@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/models/calendar_models"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+const icsContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct{ uc *usecases.CalendarUsecase }
+
+func NewCalendarHandler(uc *usecases.CalendarUsecase) *CalendarHandler { return &CalendarHandler{uc} }
+
+// @Summary      Download card as iCalendar
+// @Description  One RFC 5545 VEVENT for the card; archived cards come out as CANCELLED
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      text/calendar
+// @Param        id  path  string  true  "card id"
+// @Success      200  {string}  string  "BEGIN:VCALENDAR ..."
+// @Failure      404  {object}  errs.Problem  "card not found"
+// @Router       /interview-tracker/authen/cards/{id}/ics [get]
+func (h *CalendarHandler) CardICS(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	b, err := h.uc.CardICS(c, id)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="interview-`+id.String()+`.ics"`)
+	c.Data(http.StatusOK, icsContentType, b)
+}
+
+// @Summary      Create calendar feed URL
+// @Description  Issue a secret URL that Outlook or Google Calendar can subscribe to. The URL is only returned once.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  calendar_models.CreateFeedTokenReq  true  "feed JSON"
+// @Success      201  {object}  calendar_models.CreateFeedTokenResp
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/calendar/feeds [post]
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	var req calendar_models.CreateFeedTokenReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	session := middleware.GetSession(c)
+	res, err := h.uc.IssueFeed(c, session.UserID, req)
+	if err != nil {
+		logs.Ctx(c).Errorf("[calendar] create feed failed: %v", err)
+		middleware.Fail(c, err)
+		return
+	}
+	logs.Ctx(c).Infof("[calendar] feed created: %s", res.Prefix)
+	c.JSON(http.StatusCreated, res)
+}
+
+// @Summary      List my calendar feeds
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  entities.CalendarFeedToken
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/authen/calendar/feeds [get]
+func (h *CalendarHandler) ListFeeds(c *gin.Context) {
+	items, err := h.uc.ListFeeds(c, middleware.GetSession(c).UserID)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// @Summary      Revoke calendar feed
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "feed id"
+// @Success      200  {object}  map[string]string  "ok"
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/authen/calendar/feeds/{id} [delete]
+func (h *CalendarHandler) RevokeFeed(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.uc.RevokeFeed(c, middleware.GetSession(c).UserID, id); err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Summary      Calendar feed
+// @Description  Subscription feed of the token owner's interviews (a week back to a year ahead). No other authentication.
+// @Tags         calendar
+// @Produce      text/calendar
+// @Param        token  query  string  true  "feed token"
+// @Success      200  {string}  string  "BEGIN:VCALENDAR ..."
+// @Failure      404  {object}  errs.Problem  "unknown or revoked token"
+// @Router       /interview-tracker/calendar/feed.ics [get]
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	b, err := h.uc.Feed(c, c.Query("token"))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	// calendar clients poll; let them reuse a copy for a few minutes
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, icsContentType, b)
+}
@@ -4,13 +4,11 @@ import (
 	"interview-tracker/internal/entities"
 	"interview-tracker/internal/middleware"
 	"interview-tracker/internal/models/card_models"
-	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/i18n"
 	"interview-tracker/internal/usecases"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type CardHandler struct{ uc *usecases.CardUsecase }
@@ -18,30 +16,29 @@ type CardHandler struct{ uc *usecases.CardUsecase }
 func NewCardHandler(uc *usecases.CardUsecase) *CardHandler { return &CardHandler{uc} }
 
 // @Summary List cards
+// @Description description is Markdown; description_html is the sanitized HTML and description_excerpt plain text (limits.excerpt_length)
 // @Tags cards
 // @Security BearerAuth
 // @Produce json
 // @Param page       query int    false "page"
 // @Param page_size  query int    false "page size"
 // @Param status     query string false "Filter status" Enums(todo,in_progress,done) default(todo)
+// @Param sort       query string false "scheduled_at, or rank for board order" Enums(scheduled_at,rank) default(scheduled_at)
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards [get]
 func (h *CardHandler) List(c *gin.Context) {
 	var q card_models.ListCardsQuery
-	_ = c.ShouldBindQuery(&q)
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 || q.PageSize > 100 {
-		q.PageSize = 10
+	if !bindQuery(c, &q) {
+		return
 	}
 
-	items, total, err := h.uc.List(q.Status, q.Page, q.PageSize)
+	items, total, err := h.uc.List(c, q.Status, q.Sort, q.Page, q.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": q.Page, "page_size": q.PageSize})
+	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": q.Page, "page_size": q.PageSize, "limits": card_models.Limits})
 }
 
 // @Summary Get card detail
@@ -50,13 +47,16 @@ func (h *CardHandler) List(c *gin.Context) {
 // @Produce json
 // @Param id path string true "card id"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id} [get]
 func (h *CardHandler) Detail(c *gin.Context) {
-	idReq := c.Param("id")
-	id, _ := uuid.Parse(idReq)
-	card, err := h.uc.GetByID(id)
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	card, err := h.uc.GetByID(c, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"card": card})
@@ -69,11 +69,12 @@ func (h *CardHandler) Detail(c *gin.Context) {
 // @Produce json
 // @Param request body card_models.CreateCardReq true "card create JSON"
 // @Success 200 {object} map[string]any
+// @Failure 409 {object} errs.Problem "an interviewer is double-booked; conflicts lists the overlapping cards"
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards [post]
 func (h *CardHandler) Create(c *gin.Context) {
 	var req card_models.CreateCardReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -82,12 +83,17 @@ func (h *CardHandler) Create(c *gin.Context) {
 		Title:         req.Title,
 		Description:   req.Description,
 		CandidateName: session.Email,
+		Position:      req.Position,
 		ScheduledAt:   req.ScheduledAt,
+		EndsAt:        req.EndsAt,
+		TimeZone:      req.TimeZone,
+		Location:      req.Location,
+		MeetingURL:    req.MeetingURL,
 		CreatedBy:     session.UserID,
 		UpdatedBy:     session.UserID,
 	}
-	if err := h.uc.Create(card); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.uc.Create(c, card, uuids(req.InterviewerIDs)); err != nil {
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"card": card})
@@ -101,12 +107,16 @@ func (h *CardHandler) Create(c *gin.Context) {
 // @Param id path string true "card id"
 // @Param request body card_models.UpdateCardReq true "patch"
 // @Success 200 {object} map[string]any
+// @Failure 409 {object} errs.Problem "the card is archived, or an interviewer is double-booked; conflicts lists the overlapping cards"
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id} [patch]
 func (h *CardHandler) Update(c *gin.Context) {
-	idReq := c.Param("id")
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
 	var req card_models.UpdateCardReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 	patch := map[string]any{}
@@ -116,13 +126,31 @@ func (h *CardHandler) Update(c *gin.Context) {
 	if req.Description != nil {
 		patch["description"] = *req.Description
 	}
+	if req.Position != nil {
+		patch["position"] = *req.Position
+	}
 	if req.ScheduledAt != nil {
 		patch["scheduled_at"] = *req.ScheduledAt
 	}
-	id, _ := uuid.Parse(idReq)
-	card, err := h.uc.UpdatePartial(id, patch)
+	if req.EndsAt != nil {
+		patch["ends_at"] = *req.EndsAt
+	}
+	if req.TimeZone != nil {
+		patch["time_zone"] = *req.TimeZone
+	}
+	if req.Location != nil {
+		patch["location"] = *req.Location
+	}
+	if req.MeetingURL != nil {
+		patch["meeting_url"] = *req.MeetingURL
+	}
+	if req.InterviewerIDs != nil {
+		patch["interviewer_ids"] = uuids(*req.InterviewerIDs)
+	}
+	session := middleware.GetSession(c)
+	card, err := h.uc.UpdatePartial(c, id, patch, session.UserID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"card": card})
@@ -136,25 +164,62 @@ func (h *CardHandler) Update(c *gin.Context) {
 // @Param id path string true "card id"
 // @Param request body card_models.UpdateCardStatusReq true "new status"
 // @Success 200 {object} map[string]any
+// @Failure 409 {object} errs.Problem "the card is archived"
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id}/status [patch]
 func (h *CardHandler) UpdateStatus(c *gin.Context) {
-	idReq := c.Param("id")
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
 	var req card_models.UpdateCardStatusReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 	session := middleware.GetSession(c)
-	id, _ := uuid.Parse(idReq)
-	card, err := h.uc.UpdateStatus(id, req.Status, session.UserID)
+	card, err := h.uc.UpdateStatus(c, id, req.Status, session.UserID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"card": card})
+}
+
+// @Summary Move card
+// @Description Change the card's status and its position in the column at once.
+// @Description after_id puts it right below that card, before_id right above it; with neither it goes to the bottom of the column.
+// @Tags cards
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "card id"
+// @Param request body card_models.MoveCardReq true "target column and position"
+// @Success 200 {object} map[string]any
+// @Failure 409 {object} errs.Problem "the card is archived, or the anchor card is not in the target column"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/cards/{id}/move [post]
+func (h *CardHandler) Move(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	var req card_models.MoveCardReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	session := middleware.GetSession(c)
+	card, err := h.uc.Move(c, id, req, session.UserID)
+	if err != nil {
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"card": card})
 }
 
 // @Summary Add comment
+// @Description content is Markdown (CommonMark with GitHub tables, task lists and autolinks; raw HTML is dropped).
+// @Description Set parent_id to reply; a reply to a reply joins the same thread.
+// @Description Mention users with @name (their email before '@') or @email; mentioned users are notified.
 // @Tags comments
 // @Security BearerAuth
 // @Accept json
@@ -162,23 +227,28 @@ func (h *CardHandler) UpdateStatus(c *gin.Context) {
 // @Param id path string true "card id"
 // @Param request body card_models.AddCommentReq true "comment"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id}/comments [post]
 func (h *CardHandler) AddComment(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
 	var req card_models.AddCommentReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 	session := middleware.GetSession(c)
-	if err := h.uc.AddComment(session.UserID, id, req.Content); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	cmt, err := h.uc.AddComment(c, session.UserID, id, req)
+	if err != nil {
+		middleware.Fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "comment": cmt})
 }
 
 // @Summary Update comment
+// @Description The previous content is kept in the edit history and the comment is marked edited. Users mentioned for the first time are notified.
 // @Tags comments
 // @Security BearerAuth
 // @Accept json
@@ -186,93 +256,207 @@ func (h *CardHandler) AddComment(c *gin.Context) {
 // @Param commentId path string true "commentId"
 // @Param request body card_models.UpdateCommentReq true "patch"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/comments/{commentId} [patch]
 func (h *CardHandler) UpdateComment(c *gin.Context) {
-	idReq := c.Param("commentId")
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
 	var req card_models.UpdateCommentReq
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 	session := middleware.GetSession(c)
-	id, _ := uuid.Parse(idReq)
 
-	err := h.uc.UpdateComment(session.UserID, id, req.Content)
+	err := h.uc.UpdateComment(c, session.UserID, id, req.Content)
 	if err != nil {
-		if herr, ok := err.(*errs.HttpError); ok {
-			c.JSON(herr.Code, gin.H{"error": herr.Message})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
 // @Summary List comments
+// @Description content is Markdown, content_html the sanitized HTML and excerpt plain text.
+// @Description flat: every comment, newest first. threaded: top-level comments newest first, each with its replies (oldest first);
+// @Description page, page_size and total then count top-level comments.
+// @Description Deleted comments are left out; a deleted comment with replies heads its thread as a placeholder (content empty, is_active false).
 // @Tags comments
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "card id"
 // @Param page query int false "page"
 // @Param page_size query int false "size"
+// @Param view query string false "flat or threaded" Enums(flat,threaded) default(flat)
+// @Param include_deleted query bool false "list deleted comments in full (comment_delete only)"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id}/comments [get]
 func (h *CardHandler) ListComments(c *gin.Context) {
-	id := c.Param("id")
-	page := atoiDefault(c.Query("page"), 1)
-	size := atoiDefault(c.Query("page_size"), 10)
-	items, total, err := h.uc.ListComments(id, page, size)
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	var q card_models.ListCommentsQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	q.IncludeDeleted = q.IncludeDeleted && middleware.HasPerm(c, "comment_delete")
+	items, total, err := h.uc.ListComments(c, id, middleware.GetSession(c).UserID, q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": page, "page_size": size})
+	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": q.Page, "page_size": q.PageSize, "limits": card_models.Limits})
+}
+
+// @Summary Comment edit history
+// @Description Earlier versions of the comment, newest first; content is the text before that edit.
+// @Description Those of a deleted comment are shown to moderators (comment_delete) only.
+// @Tags comments
+// @Security BearerAuth
+// @Produce json
+// @Param commentId path string true "commentId"
+// @Success 200 {object} map[string]any "items: []entities.CommentEdit"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/cards/comments/{commentId}/edits [get]
+func (h *CardHandler) ListCommentEdits(c *gin.Context) {
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
+	items, err := h.uc.ListCommentEdits(c, id, middleware.HasPerm(c, "comment_delete"))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// @Summary React to a comment
+// @Description Adds the caller's emoji reaction (adding it again changes nothing) and returns the comment's reactions.
+// @Tags comments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param commentId path string true "commentId"
+// @Param request body card_models.ReactionReq true "emoji"
+// @Success 200 {object} map[string]any "reactions: []entities.ReactionSummary"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/cards/comments/{commentId}/reactions [post]
+func (h *CardHandler) React(c *gin.Context) {
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
+	var req card_models.ReactionReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	reactions, err := h.uc.React(c, middleware.GetSession(c).UserID, id, req.Emoji)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
+}
+
+// @Summary Remove a reaction
+// @Description Removes the caller's emoji reaction (URL-encoded in the path) and returns the comment's reactions.
+// @Tags comments
+// @Security BearerAuth
+// @Produce json
+// @Param commentId path string true "commentId"
+// @Param emoji path string true "emoji, e.g. %F0%9F%91%8D"
+// @Success 200 {object} map[string]any "reactions: []entities.ReactionSummary"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/cards/comments/{commentId}/reactions/{emoji} [delete]
+func (h *CardHandler) Unreact(c *gin.Context) {
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
+	reactions, err := h.uc.Unreact(c, middleware.GetSession(c).UserID, id, c.Param("emoji"))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
 }
 
 // @Summary Delete comment
+// @Description Soft delete: the comment leaves the lists (a thread with replies keeps a placeholder) and can be restored.
+// @Description The author (comment_edit) may delete their own comment; a moderator (comment_delete) anyone's, with a reason.
 // @Tags comments
 // @Security BearerAuth
 // @Produce json
 // @Param commentId path string true "commentId"
+// @Param reason query string false "required when deleting someone else's comment"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/comments/{commentId} [delete]
 func (h *CardHandler) DeleteComment(c *gin.Context) {
-	idReq := c.Param("commentId")
-	id, err := uuid.Parse(idReq)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid commentId"})
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
+	var q card_models.DeleteCommentQuery
+	if !bindQuery(c, &q) {
 		return
 	}
 
 	session := middleware.GetSession(c)
 
-	if err := h.uc.DeleteComment(session.UserID, id); err != nil {
-		if herr, ok := err.(*errs.HttpError); ok {
-			c.JSON(herr.Code, gin.H{"error": herr.Message})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.uc.DeleteComment(c, session.UserID, id, q.Reason, middleware.HasPerm(c, "comment_delete")); err != nil {
+		middleware.Fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// @Summary Restore comment
+// @Description Undoes a delete. A moderator (comment_delete) may restore any comment; the author only one they deleted themselves.
+// @Tags comments
+// @Security BearerAuth
+// @Produce json
+// @Param commentId path string true "commentId"
+// @Success 200 {object} map[string]any "comment: entities.CardComment"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/cards/comments/{commentId}/restore [post]
+func (h *CardHandler) RestoreComment(c *gin.Context) {
+	id, ok := paramUUID(c, "commentId")
+	if !ok {
+		return
+	}
+	cmt, err := h.uc.RestoreComment(c, middleware.GetSession(c).UserID, id, middleware.HasPerm(c, "comment_delete"))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "comment": cmt})
+}
+
 // @Summary จัดเก็บ
+// @Description Archive the card: it leaves lists and the calendar, and subscribed calendars show it as cancelled
 // @Tags cards
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "card id"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id}/keep [post]
 func (h *CardHandler) Keep(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	// session := middleware.GetSession(c)
-	if err := h.uc.Keep(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	session := middleware.GetSession(c)
+	if err := h.uc.Keep(c, id, session.UserID); err != nil {
+		middleware.Fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -286,25 +470,90 @@ func (h *CardHandler) Keep(c *gin.Context) {
 // @Param page query int false "page"
 // @Param page_size query int false "size"
 // @Success 200 {object} map[string]any
+// @Failure default {object} errs.Problem "problem details"
 // @Router /interview-tracker/authen/cards/{id}/history [get]
 func (h *CardHandler) ListHistory(c *gin.Context) {
-	id := c.Param("id")
-	page := atoiDefault(c.Query("page"), 1)
-	size := atoiDefault(c.Query("page_size"), 10)
-	items, total, err := h.uc.ListHistory(id, page, size)
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	var q card_models.PageQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	items, total, err := h.uc.ListHistory(c, id, q.Page, q.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": page, "page_size": size})
+	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": q.Page, "page_size": q.PageSize})
 }
 
-func atoiDefault(s string, def int) int {
-	if s == "" {
-		return def
+// @Summary list card statuses
+// @Description name and description follow Accept-Language (th or en)
+// @Tags cards
+// @Security BearerAuth
+// @Produce json
+// @Param Accept-Language header string false "th or en; without it the server's DEFAULT_LANGUAGE" default(en)
+// @Success 200 {array} entities.CardStatus
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/card-statuses [get]
+func (h *CardHandler) ListStatuses(c *gin.Context) {
+	items, err := h.uc.ListStatuses(c, i18n.FromContext(c))
+	if err != nil {
+		middleware.Fail(c, err)
+		return
 	}
-	if v, err := strconv.Atoi(s); err == nil {
-		return v
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary Kanban board
+// @Description Every status column with its total and its first cards in board order (rank).
+// @Description Load the rest of a column with GET /cards?status=...&sort=rank&page=2&page_size={limit}.
+// @Tags cards
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "cards per column" default(20)
+// @Param Accept-Language header string false "th or en; without it the server's DEFAULT_LANGUAGE" default(en)
+// @Success 200 {object} map[string]any "columns: []card_models.BoardColumn"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/board [get]
+func (h *CardHandler) Board(c *gin.Context) {
+	var q card_models.BoardQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	columns, err := h.uc.Board(c, i18n.FromContext(c), q.Limit)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"columns": columns, "limit": q.Limit, "limits": card_models.Limits})
+}
+
+// @Summary Interview calendar
+// @Description Interviews overlapping [from, to) for an interviewer (user_id) or a position.
+// @Description Without either filter the caller's own interviews are returned. The range may be at most 93 days.
+// @Tags cards
+// @Security BearerAuth
+// @Produce json
+// @Param from     query string true  "range start (RFC 3339)" default(2030-01-01T00:00:00+07:00)
+// @Param to       query string true  "range end, exclusive (RFC 3339)" default(2030-01-08T00:00:00+07:00)
+// @Param user_id  query string false "interviewer id"
+// @Param position query string false "position, case-insensitive"
+// @Param tz       query string false "IANA time zone for the returned times (default: each card's own)"
+// @Success 200 {object} map[string]any "items: []card_models.CalendarEvent"
+// @Failure default {object} errs.Problem "problem details"
+// @Router /interview-tracker/authen/calendar [get]
+func (h *CardHandler) Calendar(c *gin.Context) {
+	var q card_models.CalendarQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	items, err := h.uc.Calendar(c, q, middleware.GetSession(c).UserID)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
 	}
-	return def
+	c.JSON(http.StatusOK, gin.H{"items": items, "from": q.From, "to": q.To})
 }
@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 
+	"interview-tracker/internal/usecases"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,3 +18,35 @@ import (
 func GetHealth(c *gin.Context) {
 	c.JSON(http.StatusOK, "success")
 }
+
+type HealthHandler struct{ uc *usecases.HealthUsecase }
+
+func NewHealthHandler(uc *usecases.HealthUsecase) *HealthHandler { return &HealthHandler{uc} }
+
+// Live godoc
+// @Summary      Liveness probe
+// @Description  Returns 200 while the process is running; does not touch dependencies
+// @Tags         health
+// @Produce      json
+// @Success      200 {object} map[string]string
+// @Router       /interview-tracker/health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": usecases.HealthOK})
+}
+
+// Ready godoc
+// @Summary      Readiness probe
+// @Description  Checks database, redis, migration state and JWT keys; 503 when any check fails
+// @Tags         health
+// @Produce      json
+// @Success      200 {object} usecases.Readiness
+// @Failure      503 {object} usecases.Readiness
+// @Router       /interview-tracker/health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	res := h.uc.Ready(c.Request.Context())
+	code := http.StatusOK
+	if res.Status != usecases.HealthOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, res)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SSOHandler struct{ sso usecases.SSOUsecase }
+
+func NewSSOHandler(s usecases.SSOUsecase) *SSOHandler { return &SSOHandler{sso: s} }
+
+// @Summary      SSO login
+// @Description  Redirect to the corporate identity provider (OIDC authorization code + PKCE)
+// @Tags         auth
+// @Success      302
+// @Failure      502  {object}  errs.Problem  "identity provider unavailable"
+// @Router       /interview-tracker/internal/v1/auth/sso/login [get]
+func (h *SSOHandler) Login(c *gin.Context) {
+	logs.Ctx(c).Infof("[sso] login start...")
+	url, err := h.sso.AuthURL(c)
+	if err != nil {
+		logs.Ctx(c).Errorf("[sso] auth url error: %v", err)
+		middleware.Fail(c, errs.New(http.StatusBadGateway, "idp_unavailable", "identity provider unavailable"))
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// @Summary      SSO callback
+// @Description  Exchange the authorization code and issue access/refresh tokens (same shape as password login)
+// @Tags         auth
+// @Produce      json
+// @Param        code   query  string  true  "authorization code"
+// @Param        state  query  string  true  "state"
+// @Success      200    {object} auth_models.TokenResponse  "Success"
+// @Failure      401    {object} errs.Problem  "sso failed"
+// @Failure      403    {object} errs.Problem  "user not provisioned, or account disabled"
+// @Router       /interview-tracker/internal/v1/auth/sso/callback [get]
+func (h *SSOHandler) Callback(c *gin.Context) {
+	logs.Ctx(c).Infof("[sso] callback start...")
+	if e := c.Query("error"); e != "" {
+		logs.Ctx(c).Warnf("[sso] idp error: %s %s", e, c.Query("error_description"))
+		middleware.Fail(c, usecases.ErrSSOFailed)
+		return
+	}
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		middleware.Fail(c, errs.BadRequest("missing code or state").WithCode("missing_code_or_state"))
+		return
+	}
+
+	access, refresh, refID, err := h.sso.Callback(c, state, code)
+	if err != nil {
+		logs.Ctx(c).Warnf("[sso] callback error: %v", err)
+		metrics.Logins.WithLabelValues("sso", "failure").Inc()
+		// the reason (bad state, nonce, IdP error) is logged, not returned
+		if !errors.Is(err, usecases.ErrSSOUserNotProvisioned) && !errors.Is(err, usecases.ErrSSOAccountDisabled) {
+			err = usecases.ErrSSOFailed
+		}
+		middleware.Fail(c, err)
+		return
+	}
+
+	logs.Ctx(c).Infof("[sso] login success...")
+	metrics.Logins.WithLabelValues("sso", "success").Inc()
+	c.JSON(http.StatusOK, gin.H{"access_token": access, "refresh_token": refresh, "ref_id": refID})
+}
@@ -3,9 +3,10 @@ package handlers
 import (
 	"net/http"
 
+	"interview-tracker/internal/middleware"
 	"interview-tracker/internal/models/user_models"
+	"interview-tracker/internal/pkg/i18n"
 	"interview-tracker/internal/pkg/logs"
-	"interview-tracker/internal/pkg/utils"
 	"interview-tracker/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -26,32 +27,26 @@ func NewUserHandler(usecase *usecases.UserUsecase) *UserHandler {
 // @Tags         users
 // @Param        request  body  user_models.CreateUserRequest  true  "user create JSON"
 // @Success      201      {object} user_models.CreateUserResponse  "Success response"
-// @Failure      400      {object} map[string]string "Bad Request"
+// @Failure      400      {object} errs.Problem "Bad Request"
+// @Failure      422      {object} errs.Problem "Validation failed"
 // @Router       /interview-tracker/internal/v1/users/create [post]
 func (h *UserHandler) Create(c *gin.Context) {
-	logs.Logger.Printf("[user] create start...")
+	logs.Ctx(c).Infof("[user] create start...")
 
 	var request user_models.CreateUserRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
-		logs.Logger.Printf("[user] create bind error: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &request) {
+		logs.Ctx(c).Warnf("[user] create invalid request: %v", c.Errors.Last())
 		return
 	}
 
-	if mapErr := utils.ValidateRequest(request); mapErr != nil {
-		logs.Logger.Printf("[user] create validate error: %+v", mapErr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": mapErr})
-		return
-	}
-
-	res, err := h.usecase.CreateUser(request)
+	res, err := h.usecase.CreateUser(c, request)
 	if err != nil {
-		logs.Logger.Printf("[user] create failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		logs.Ctx(c).Warnf("[user] create failed: %v", err)
+		middleware.Fail(c, err)
 		return
 	}
 
-	logs.Logger.Printf("[user] create success")
+	logs.Ctx(c).Infof("[user] create success")
 	c.JSON(http.StatusCreated, res)
 }
 
@@ -62,20 +57,23 @@ func (h *UserHandler) Create(c *gin.Context) {
 // @Tags users
 // @Param userId path string true "User ID" default(888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3)
 // @Success 200 {object} entities.User "Successful response"
-// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 404 {object} errs.Problem "Not Found"
 // @Router /interview-tracker/internal/v1/users/details/{userId} [get]
 func (h *UserHandler) GetById(c *gin.Context) {
-	logs.Logger.Printf("[user] GetById start....")
+	logs.Ctx(c).Infof("[user] GetById start....")
 
-	userId := c.Param("userId")
-	resp, err := h.usecase.GetUserById(userId)
+	userId, ok := paramUUID(c, "userId")
+	if !ok {
+		return
+	}
+	resp, err := h.usecase.GetUserById(c, userId.String())
 	if err != nil {
-		logs.Logger.Printf("[user] GetById failed: %v", err)
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		logs.Ctx(c).Warnf("[user] GetById failed: %v", err)
+		middleware.Fail(c, err)
 		return
 	}
 
-	logs.Logger.Printf("[user] GetById success....")
+	logs.Ctx(c).Infof("[user] GetById success....")
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -85,18 +83,41 @@ func (h *UserHandler) GetById(c *gin.Context) {
 // @Produce json
 // @Tags users
 // @Success 200 {array} entities.Role "Successful response"
-// @Failure 500 {object} map[string]string "Server error"
+// @Failure 500 {object} errs.Problem "Server error"
 // @Router /interview-tracker/internal/v1/users/role-list [get]
 func (h *UserHandler) GetListRole(c *gin.Context) {
-	logs.Logger.Printf("[user] ListRole start....")
+	logs.Ctx(c).Infof("[user] ListRole start....")
 
-	resp, err := h.usecase.GetRoleList()
+	resp, err := h.usecase.GetRoleList(c)
+	if err != nil {
+		logs.Ctx(c).Errorf("[user] ListRole failed: %v", err)
+		middleware.Fail(c, err)
+		return
+	}
+
+	logs.Ctx(c).Infof("[user] ListRole success....")
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Get permission
+// @Description  List active permissions; name and description follow Accept-Language (th or en)
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Accept-Language header string false "th or en; without it the server's DEFAULT_LANGUAGE" default(en)
+// @Success 200 {array} entities.Permission "Successful response"
+// @Failure 500 {object} errs.Problem "Server error"
+// @Router /interview-tracker/internal/v1/users/permission-list [get]
+func (h *UserHandler) GetListPermission(c *gin.Context) {
+	logs.Ctx(c).Infof("[user] ListPermission start....")
+
+	resp, err := h.usecase.GetPermissionList(c, i18n.FromContext(c))
 	if err != nil {
-		logs.Logger.Printf("[user] ListRole failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		logs.Ctx(c).Errorf("[user] ListPermission failed: %v", err)
+		middleware.Fail(c, err)
 		return
 	}
 
-	logs.Logger.Printf("[user] ListRole success....")
+	logs.Ctx(c).Infof("[user] ListPermission success....")
 	c.JSON(http.StatusOK, resp)
 }
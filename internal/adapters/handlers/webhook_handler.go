@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/models/webhook_models"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct{ uc *usecases.WebhookUsecase }
+
+func NewWebhookHandler(uc *usecases.WebhookUsecase) *WebhookHandler { return &WebhookHandler{uc} }
+
+// @Summary      Create webhook
+// @Description  Subscribe a URL to card and comment events. Requests are signed with HMAC-SHA256 using the returned secret, which is only shown once.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  webhook_models.CreateWebhookReq  true  "webhook JSON"
+// @Success      201  {object}  webhook_models.CreateWebhookResp
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/internal/v1/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	logs.Ctx(c).Infof("[webhook] create start...")
+	var req webhook_models.CreateWebhookReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	session := middleware.GetSession(c)
+	res, err := h.uc.Create(c, session.UserID, req)
+	if err != nil {
+		logs.Ctx(c).Errorf("[webhook] create failed: %v", err)
+		middleware.Fail(c, err)
+		return
+	}
+	logs.Ctx(c).Infof("[webhook] create success: %s", res.ID)
+	c.JSON(http.StatusCreated, res)
+}
+
+// @Summary      List webhooks
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  entities.Webhook
+// @Failure default {object} errs.Problem "problem details"
+// @Router       /interview-tracker/internal/v1/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	items, err := h.uc.List(c)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// @Summary      Get webhook
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "webhook id"
+// @Success      200  {object}  entities.Webhook
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/webhooks/{id} [get]
+func (h *WebhookHandler) Detail(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	w, err := h.uc.GetByID(c, id)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+// @Summary      Update webhook
+// @Description  Change the URL, description or events, or enable/disable it. Enabling resets the failure count.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true  "webhook id"
+// @Param        request  body  webhook_models.UpdateWebhookReq  true  "patch"
+// @Success      200  {object}  entities.Webhook
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/webhooks/{id} [patch]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	var req webhook_models.UpdateWebhookReq
+	if !bindJSON(c, &req) {
+		return
+	}
+	session := middleware.GetSession(c)
+	w, err := h.uc.Update(c, session.UserID, id, req)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+// @Summary      Delete webhook
+// @Description  Deletes the webhook and its delivery log
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "webhook id"
+// @Success      200  {object}  map[string]string  "ok"
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.uc.Delete(c, id); err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Summary      List deliveries
+// @Description  The delivery log, newest first
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "webhook id"
+// @Param        page  query  int  false  "page"
+// @Param        page_size  query  int  false  "size"
+// @Param        status  query  string  false  "pending, succeeded or failed"
+// @Success      200  {object}  map[string]any
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	var q webhook_models.ListDeliveriesQuery
+	if !bindQuery(c, &q) {
+		return
+	}
+	items, total, err := h.uc.ListDeliveries(c, id, q)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items, "total": total, "page": q.Page, "page_size": q.PageSize})
+}
+
+// @Summary      Replay delivery
+// @Description  Queue the same event again as a new delivery
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  string  true  "webhook id"
+// @Param        deliveryId  path  string  true  "delivery id"
+// @Success      202  {object}  entities.WebhookDelivery
+// @Failure      404  {object}  errs.Problem  "not found"
+// @Router       /interview-tracker/internal/v1/webhooks/{id}/deliveries/{deliveryId}/replay [post]
+func (h *WebhookHandler) Replay(c *gin.Context) {
+	id, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := paramUUID(c, "deliveryId")
+	if !ok {
+		return
+	}
+	d, err := h.uc.Replay(c, id, deliveryID)
+	if err != nil {
+		middleware.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, d)
+}
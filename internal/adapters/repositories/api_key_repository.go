@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"interview-tracker/internal/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepository interface {
+	Create(ctx context.Context, key *entities.ApiKey) error
+	GetByHash(ctx context.Context, hash string) (*entities.ApiKey, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ApiKey, error)
+	List(ctx context.Context, createdBy *uuid.UUID) ([]*entities.ApiKey, error)
+	Revoke(ctx context.Context, id, actor uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type apiKeyRepo struct{ db *gorm.DB }
+
+func NewApiKeyRepo(db *gorm.DB) ApiKeyRepository { return &apiKeyRepo{db} }
+
+func (r *apiKeyRepo) Create(ctx context.Context, k *entities.ApiKey) error {
+	return r.db.WithContext(ctx).Create(k).Error
+}
+
+func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*entities.ApiKey, error) {
+	var k entities.ApiKey
+	if err := r.db.WithContext(ctx).First(&k, "key_hash = ? AND is_active = true", hash).Error; err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.ApiKey, error) {
+	var k entities.ApiKey
+	if err := r.db.WithContext(ctx).First(&k, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// List returns every key, or only the keys issued by createdBy when it is set
+func (r *apiKeyRepo) List(ctx context.Context, createdBy *uuid.UUID) ([]*entities.ApiKey, error) {
+	var list []*entities.ApiKey
+	qb := r.db.WithContext(ctx).Model(&entities.ApiKey{})
+	if createdBy != nil {
+		qb = qb.Where("created_by = ?", *createdBy)
+	}
+	if err := qb.Order("created_at desc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, id, actor uuid.UUID) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&entities.ApiKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_active":  false,
+			"revoked_at": now,
+			"updated_at": now,
+			"updated_by": actor,
+		}).Error
+}
+
+// TouchLastUsed records usage at most once a minute per key to avoid a write on every request
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.ApiKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-time.Minute)).
+		UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *entities.CardAttachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CardAttachment, error)
+	// ListByCard returns the card's attachments, newest first
+	ListByCard(ctx context.Context, cardID uuid.UUID) ([]*entities.CardAttachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type attachmentRepo struct{ db *gorm.DB }
+
+func NewAttachmentRepo(db *gorm.DB) AttachmentRepository { return &attachmentRepo{db} }
+
+func (r *attachmentRepo) Create(ctx context.Context, a *entities.CardAttachment) error {
+	return conn(ctx, r.db).Create(a).Error
+}
+
+func (r *attachmentRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.CardAttachment, error) {
+	var a entities.CardAttachment
+	if err := conn(ctx, r.db).First(&a, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *attachmentRepo) ListByCard(ctx context.Context, cardID uuid.UUID) ([]*entities.CardAttachment, error) {
+	var list []*entities.CardAttachment
+	if err := conn(ctx, r.db).Where("card_id = ?", cardID).Order("created_at desc, id").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *attachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&entities.CardAttachment{}, "id = ?", id).Error
+}
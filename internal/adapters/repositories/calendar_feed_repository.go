@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedRepository interface {
+	Create(ctx context.Context, t *entities.CalendarFeedToken) error
+	GetByHash(ctx context.Context, hash string) (*entities.CalendarFeedToken, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CalendarFeedToken, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CalendarFeedToken, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type calendarFeedRepo struct{ db *gorm.DB }
+
+func NewCalendarFeedRepo(db *gorm.DB) CalendarFeedRepository { return &calendarFeedRepo{db} }
+
+func (r *calendarFeedRepo) Create(ctx context.Context, t *entities.CalendarFeedToken) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+func (r *calendarFeedRepo) GetByHash(ctx context.Context, hash string) (*entities.CalendarFeedToken, error) {
+	var t entities.CalendarFeedToken
+	// a deactivated user's feeds stop working without being revoked
+	if err := r.db.WithContext(ctx).
+		Where("user_id IN (?)", r.db.Model(&entities.User{}).Select("id").Where("is_active = TRUE")).
+		First(&t, "token_hash = ? AND is_active = true", hash).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *calendarFeedRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.CalendarFeedToken, error) {
+	var t entities.CalendarFeedToken
+	if err := r.db.WithContext(ctx).First(&t, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *calendarFeedRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CalendarFeedToken, error) {
+	var list []*entities.CalendarFeedToken
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *calendarFeedRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&entities.CalendarFeedToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_active":  false,
+			"revoked_at": now,
+			"updated_at": now,
+		}).Error
+}
+
+// TouchLastUsed records a poll at most once a minute per token
+func (r *calendarFeedRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.CalendarFeedToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-time.Minute)).
+		UpdateColumn("last_used_at", at).Error
+}
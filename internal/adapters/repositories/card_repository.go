@@ -1,56 +1,182 @@
 package repositories
 
 import (
+	"context"
+	"sort"
+	"time"
+
 	"interview-tracker/internal/entities"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CardRepository interface {
-	Create(card *entities.Card) error
-	Update(card *entities.Card) error
-	GetByID(id uuid.UUID) (*entities.Card, error)
-	List(status string, page, size int) ([]*entities.Card, int64, error)
-	DeleteCard(id string) error
+	Schedule(ctx context.Context, card *entities.Card) ([]*entities.Card, error)
+	// Update saves every column of the card, so the card must have been read with GetForUpdate in the same transaction
+	Update(ctx context.Context, card *entities.Card) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Card, error)
+	// GetForUpdate reads the card and locks its row until the current transaction ends
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*entities.Card, error)
+	List(ctx context.Context, status, sort string, page, size int) ([]*entities.Card, int64, error)
+	Calendar(ctx context.Context, f CalendarFilter) ([]*entities.Card, error)
+	Archive(ctx context.Context, id, actor uuid.UUID) error
+	ListStatuses(ctx context.Context) ([]*entities.CardStatus, error)
+
+	// Board returns the first perColumn active cards of every status, by rank
+	Board(ctx context.Context, perColumn int) ([]*entities.Card, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	// LockColumn serializes rank changes in a status column until the current transaction ends
+	LockColumn(ctx context.Context, status string) error
+	// Rank returns the rank of the active card id in status; gorm.ErrRecordNotFound if it is not there
+	Rank(ctx context.Context, status string, id uuid.UUID) (string, error)
+	// AdjacentRank returns the rank of the card right after (or before) rank/id in status,
+	// skipping exclude; "" when there is none
+	AdjacentRank(ctx context.Context, status, rank string, id, exclude uuid.UUID, after bool) (string, error)
+	// LastRank returns the highest rank in status, skipping exclude; "" for an empty column
+	LastRank(ctx context.Context, status string, exclude uuid.UUID) (string, error)
+	// Rebalance renumbers the column with evenly spaced short ranks (rank.Spread), keeping the order
+	Rebalance(ctx context.Context, status string) error
+
+	AddComment(ctx context.Context, c *entities.CardComment) error
+	UpdateComment(ctx context.Context, c *entities.CardComment) error
+	GetCommentByID(ctx context.Context, commentId uuid.UUID) (*entities.CardComment, error)
+	// ListComments pages active comments newest first, or only top-level ones with topLevel (including
+	// deleted ones that still have active replies). withDeleted lists deleted comments as well.
+	ListComments(ctx context.Context, cardID uuid.UUID, topLevel, withDeleted bool, page, size int) ([]*entities.CardComment, int64, error)
+	// ListReplies returns the active replies to the given comments (all of them withDeleted), oldest first
+	ListReplies(ctx context.Context, parentIDs []uuid.UUID, withDeleted bool) ([]*entities.CardComment, error)
+	// UpdateCommentDeletion saves is_active and the deleted_* fields: soft delete or restore
+	UpdateCommentDeletion(ctx context.Context, c *entities.CardComment) error
+
+	AddCommentEdit(ctx context.Context, e *entities.CommentEdit) error
+	ListCommentEdits(ctx context.Context, commentID uuid.UUID) ([]*entities.CommentEdit, error)
+	// SetMentions makes list the comment's mentions, keeping the ones it already had
+	SetMentions(ctx context.Context, commentID uuid.UUID, list []entities.CommentMention) error
+	// MentionUsers returns active users holding perm whose email, or the part of it before '@',
+	// is one of handles (lower case)
+	MentionUsers(ctx context.Context, handles []string, perm string) ([]*entities.User, error)
+	Users(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error)
 
-	AddComment(c *entities.CardComment) error
-	UpdateComment(c *entities.CardComment) error
-	GetCommentByID(commentId uuid.UUID) (*entities.CardComment, error)
-	ListComments(cardID string, page, size int) ([]*entities.CardComment, int64, error)
-	DeleteCommentByID(commentId uuid.UUID) error
+	// AddReaction ignores a reaction the user already made
+	AddReaction(ctx context.Context, r *entities.CommentReaction) error
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) error
+	ListReactions(ctx context.Context, commentIDs []uuid.UUID) ([]*entities.CommentReaction, error)
 
-	AddHistory(p *entities.CardHistoryLogs) error
-	ListHistory(cardID string, page, size int) ([]*entities.CardHistoryLogs, int64, error)
+	AddHistory(ctx context.Context, p *entities.CardHistoryLogs) error
+	ListHistory(ctx context.Context, cardID uuid.UUID, page, size int) ([]*entities.CardHistoryLogs, int64, error)
+}
+
+// CalendarFilter selects cards overlapping [From, To); UserID and Position are optional.
+// Archived cards are only returned with IncludeArchived (calendar feeds show them as cancelled).
+type CalendarFilter struct {
+	From, To        time.Time
+	UserID          *uuid.UUID
+	Position        string
+	IncludeArchived bool
+	Limit           int
 }
 
 type cardRepo struct{ db *gorm.DB }
 
 func NewCardRepo(db *gorm.DB) CardRepository { return &cardRepo{db} }
 
-func (r *cardRepo) Create(c *entities.Card) error { return r.db.Create(c).Error }
+// Schedule creates (zero ID) or updates the card and replaces its interviewers in one
+// transaction, unless one of the interviewers already has another card overlapping
+// [ScheduledAt, EndsAt). In that case nothing is written and the overlapping cards are returned.
+// Interviewers are locked with advisory locks so two requests cannot book the same slot at once.
+func (r *cardRepo) Schedule(ctx context.Context, c *entities.Card) ([]*entities.Card, error) {
+	var conflicts []*entities.Card
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		ids := c.InterviewerIDs()
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+		for _, id := range ids {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "card_interviewer:"+id.String()).Error; err != nil {
+				return err
+			}
+		}
+		if len(ids) > 0 {
+			if err := tx.Preload("Interviewers").
+				Where("id <> ? AND is_active = TRUE", c.ID).
+				Where("scheduled_at < ? AND ends_at > ?", c.EndsAt, c.ScheduledAt).
+				Where("id IN (?)", tx.Model(&entities.CardInterviewer{}).Select("card_id").Where("user_id IN ?", ids)).
+				Order("scheduled_at asc").
+				Find(&conflicts).Error; err != nil {
+				return err
+			}
+			if len(conflicts) > 0 {
+				return nil
+			}
+		}
 
-func (r *cardRepo) Update(c *entities.Card) error { return r.db.Save(c).Error }
+		interviewers := c.Interviewers
+		save := tx.Omit(clause.Associations)
+		if c.ID == uuid.Nil {
+			if err := save.Create(c).Error; err != nil {
+				return err
+			}
+		} else if err := save.Save(c).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("card_id = ?", c.ID).Delete(&entities.CardInterviewer{}).Error; err != nil {
+			return err
+		}
+		for i := range interviewers {
+			interviewers[i].CardID = c.ID
+		}
+		if len(interviewers) > 0 {
+			if err := tx.Create(&interviewers).Error; err != nil {
+				return err
+			}
+		}
+		c.Interviewers = interviewers
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (r *cardRepo) Update(ctx context.Context, c *entities.Card) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Save(c).Error
+}
+
+func (r *cardRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.Card, error) {
+	var card entities.Card
+	if err := conn(ctx, r.db).Preload("Interviewers").First(&card, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
 
-func (r *cardRepo) GetByID(id uuid.UUID) (*entities.Card, error) {
+func (r *cardRepo) GetForUpdate(ctx context.Context, id uuid.UUID) (*entities.Card, error) {
 	var card entities.Card
-	if err := r.db.First(&card, "id = ?", id).Error; err != nil {
+	if err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Interviewers").
+		First(&card, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &card, nil
 }
 
-func (r *cardRepo) List(status string, page, size int) ([]*entities.Card, int64, error) {
+func (r *cardRepo) List(ctx context.Context, status, sort string, page, size int) ([]*entities.Card, int64, error) {
 	var list []*entities.Card
 	var total int64
-	qb := r.db.Model(&entities.Card{})
+	qb := conn(ctx, r.db).Model(&entities.Card{}).Where("is_active = TRUE")
 	if status != "" {
 		qb = qb.Where("status_code = ?", status)
 	}
 	if err := qb.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
-	if err := qb.Order("scheduled_at asc").
+	order := "scheduled_at asc"
+	if sort == "rank" {
+		order = "status_code asc, rank asc, id asc"
+	}
+	if err := qb.Preload("Interviewers").
+		Order(order).
 		Limit(size).
 		Offset((page - 1) * size).
 		Find(&list).Error; err != nil {
@@ -59,35 +185,90 @@ func (r *cardRepo) List(status string, page, size int) ([]*entities.Card, int64,
 	return list, total, nil
 }
 
-func (r *cardRepo) AddComment(cmt *entities.CardComment) error {
-	return r.db.Create(cmt).Error
+func (r *cardRepo) Calendar(ctx context.Context, f CalendarFilter) ([]*entities.Card, error) {
+	var list []*entities.Card
+	qb := conn(ctx, r.db).
+		Preload("Interviewers").
+		Where("scheduled_at < ? AND ends_at > ?", f.To, f.From)
+	if f.UserID != nil {
+		qb = qb.Where("id IN (?)", r.db.Model(&entities.CardInterviewer{}).Select("card_id").Where("user_id = ?", *f.UserID))
+	}
+	if f.Position != "" {
+		qb = qb.Where("lower(position) = lower(?)", f.Position)
+	}
+	if !f.IncludeArchived {
+		qb = qb.Where("is_active = TRUE")
+	}
+	if err := qb.Order("scheduled_at asc").Limit(f.Limit).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) AddComment(ctx context.Context, cmt *entities.CardComment) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Create(cmt).Error
 }
 
-func (r *cardRepo) ListComments(cardID string, page, size int) ([]*entities.CardComment, int64, error) {
+func (r *cardRepo) ListComments(ctx context.Context, cardID uuid.UUID, topLevel, withDeleted bool, page, size int) ([]*entities.CardComment, int64, error) {
 	var list []*entities.CardComment
 	var total int64
-	qb := r.db.Model(&entities.CardComment{}).Where("card_id = ?", cardID)
+	qb := conn(ctx, r.db).Model(&entities.CardComment{}).Where("card_id = ?", cardID)
+	if topLevel {
+		qb = qb.Where("parent_id IS NULL")
+	}
+	switch {
+	case withDeleted:
+	case topLevel:
+		// a deleted comment stays as a placeholder while its thread has replies
+		qb = qb.Where("is_active = TRUE OR EXISTS (SELECT 1 FROM card_comments r WHERE r.parent_id = card_comments.id AND r.is_active = TRUE)")
+	default:
+		qb = qb.Where("is_active = TRUE")
+	}
 	if err := qb.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
-	if err := qb.Order("created_at desc").Limit(size).Offset((page - 1) * size).Find(&list).Error; err != nil {
+	if err := qb.Preload("Mentions").Order("created_at desc").Limit(size).Offset((page - 1) * size).Find(&list).Error; err != nil {
 		return nil, 0, err
 	}
 	return list, total, nil
 }
 
-func (r *cardRepo) DeleteCommentByID(commentId uuid.UUID) error {
-	return r.db.Delete(&entities.CardComment{}, "id = ?", commentId).Error
+func (r *cardRepo) ListReplies(ctx context.Context, parentIDs []uuid.UUID, withDeleted bool) ([]*entities.CardComment, error) {
+	var list []*entities.CardComment
+	if len(parentIDs) == 0 {
+		return list, nil
+	}
+	qb := conn(ctx, r.db).Preload("Mentions").Where("parent_id IN ?", parentIDs)
+	if !withDeleted {
+		qb = qb.Where("is_active = TRUE")
+	}
+	if err := qb.Order("created_at asc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) UpdateCommentDeletion(ctx context.Context, c *entities.CardComment) error {
+	return conn(ctx, r.db).Model(&entities.CardComment{}).
+		Where("id = ?", c.ID).
+		Updates(map[string]interface{}{
+			"is_active":     c.IsActive,
+			"deleted_at":    c.DeletedAt,
+			"deleted_by":    c.DeletedBy,
+			"delete_reason": c.DeleteReason,
+			"updated_at":    c.UpdatedAt,
+			"updated_by":    c.UpdatedBy,
+		}).Error
 }
 
-func (r *cardRepo) AddHistory(p *entities.CardHistoryLogs) error {
-	return r.db.Create(p).Error
+func (r *cardRepo) AddHistory(ctx context.Context, p *entities.CardHistoryLogs) error {
+	return conn(ctx, r.db).Create(p).Error
 }
 
-func (r *cardRepo) ListHistory(cardID string, page, size int) ([]*entities.CardHistoryLogs, int64, error) {
+func (r *cardRepo) ListHistory(ctx context.Context, cardID uuid.UUID, page, size int) ([]*entities.CardHistoryLogs, int64, error) {
 	var list []*entities.CardHistoryLogs
 	var total int64
-	qb := r.db.Model(&entities.CardHistoryLogs{}).Where("card_id = ?", cardID)
+	qb := conn(ctx, r.db).Model(&entities.CardHistoryLogs{}).Where("card_id = ?", cardID)
 	if err := qb.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -97,25 +278,214 @@ func (r *cardRepo) ListHistory(cardID string, page, size int) ([]*entities.CardH
 	return list, total, nil
 }
 
-func (r *cardRepo) DeleteCard(id string) error {
-	return r.db.Delete(&entities.Card{}, "id = ?", id).Error
+// Archive hides the card from lists and bumps its sequence so calendars cancel the event
+func (r *cardRepo) Archive(ctx context.Context, id, actor uuid.UUID) error {
+	return conn(ctx, r.db).Model(&entities.Card{}).
+		Where("id = ? AND is_active = TRUE", id).
+		Updates(map[string]interface{}{
+			"is_active":  false,
+			"sequence":   gorm.Expr("sequence + 1"),
+			"updated_at": time.Now(),
+			"updated_by": actor,
+		}).Error
 }
 
-func (r *cardRepo) UpdateComment(c *entities.CardComment) error {
-	return r.db.Model(&entities.CardComment{}).
+func (r *cardRepo) UpdateComment(ctx context.Context, c *entities.CardComment) error {
+	return conn(ctx, r.db).Model(&entities.CardComment{}).
 		Where("id = ?", c.ID).
 		Updates(map[string]interface{}{
 			"content":    c.Content,
 			"author_id":  c.AuthorID,
+			"edited":     c.Edited,
+			"edited_at":  c.EditedAt,
 			"updated_at": c.UpdatedAt,
 			"updated_by": c.UpdatedBy,
 		}).Error
 }
 
-func (r *cardRepo) GetCommentByID(commentId uuid.UUID) (*entities.CardComment, error) {
+func (r *cardRepo) GetCommentByID(ctx context.Context, commentId uuid.UUID) (*entities.CardComment, error) {
 	var comment entities.CardComment
-	if err := r.db.First(&comment, "id = ?", commentId).Error; err != nil {
+	if err := conn(ctx, r.db).Preload("Mentions").First(&comment, "id = ?", commentId).Error; err != nil {
 		return nil, err
 	}
 	return &comment, nil
 }
+
+func (r *cardRepo) AddCommentEdit(ctx context.Context, e *entities.CommentEdit) error {
+	return conn(ctx, r.db).Create(e).Error
+}
+
+func (r *cardRepo) ListCommentEdits(ctx context.Context, commentID uuid.UUID) ([]*entities.CommentEdit, error) {
+	var list []*entities.CommentEdit
+	if err := conn(ctx, r.db).Where("comment_id = ?", commentID).Order("edited_at desc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) SetMentions(ctx context.Context, commentID uuid.UUID, list []entities.CommentMention) error {
+	db := conn(ctx, r.db)
+	ids := make([]uuid.UUID, 0, len(list))
+	for i := range list {
+		list[i].CommentID = commentID
+		ids = append(ids, list[i].UserID)
+	}
+	qb := db.Where("comment_id = ?", commentID)
+	if len(ids) > 0 {
+		qb = qb.Where("user_id NOT IN ?", ids)
+	}
+	if err := qb.Delete(&entities.CommentMention{}).Error; err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&list).Error
+}
+
+func (r *cardRepo) MentionUsers(ctx context.Context, handles []string, perm string) ([]*entities.User, error) {
+	var users []*entities.User
+	if len(handles) == 0 {
+		return users, nil
+	}
+	// same joins as userRepo.GetPermissionsByUserID
+	const hasPerm = `EXISTS (
+		SELECT 1 FROM roles r
+		JOIN role_permissions rp ON rp.role_id = r.id AND rp.is_active = true
+		JOIN permissions p ON p.id = rp.permission_id AND p.is_active = true
+		WHERE r.id = users.role_id AND r.is_active = true AND p.code = ?)`
+	if err := conn(ctx, r.db).
+		Where("is_active = TRUE").
+		Where("lower(email) IN ? OR lower(split_part(email, '@', 1)) IN ?", handles, handles).
+		Where(hasPerm, perm).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *cardRepo) Users(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	var users []*entities.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := conn(ctx, r.db).Where("id IN ? AND is_active = TRUE", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *cardRepo) AddReaction(ctx context.Context, re *entities.CommentReaction) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(re).Error
+}
+
+func (r *cardRepo) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) error {
+	return conn(ctx, r.db).
+		Where("comment_id = ? AND user_id = ? AND emoji = ?", commentID, userID, emoji).
+		Delete(&entities.CommentReaction{}).Error
+}
+
+func (r *cardRepo) ListReactions(ctx context.Context, commentIDs []uuid.UUID) ([]*entities.CommentReaction, error) {
+	var list []*entities.CommentReaction
+	if len(commentIDs) == 0 {
+		return list, nil
+	}
+	if err := conn(ctx, r.db).Where("comment_id IN ?", commentIDs).Order("created_at asc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) ListStatuses(ctx context.Context) ([]*entities.CardStatus, error) {
+	var list []*entities.CardStatus
+	// todo -> in_progress -> done ตามลำดับการทำงาน
+	if err := conn(ctx, r.db).
+		Order("CASE status_code WHEN 'todo' THEN 1 WHEN 'in_progress' THEN 2 WHEN 'done' THEN 3 ELSE 4 END").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) Board(ctx context.Context, perColumn int) ([]*entities.Card, error) {
+	db := conn(ctx, r.db)
+	ranked := db.Model(&entities.Card{}).
+		Select("cards.*, row_number() OVER (PARTITION BY status_code ORDER BY rank, id) AS board_pos").
+		Where("is_active = TRUE")
+	var list []*entities.Card
+	if err := db.Table("(?) AS cards", ranked).
+		Where("board_pos <= ?", perColumn).
+		Preload("Interviewers").
+		Order("status_code asc, rank asc, id asc").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *cardRepo) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		StatusCode string
+		Total      int64
+	}
+	if err := conn(ctx, r.db).Model(&entities.Card{}).
+		Select("status_code, count(*) AS total").
+		Where("is_active = TRUE").
+		Group("status_code").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		out[row.StatusCode] = row.Total
+	}
+	return out, nil
+}
+
+func (r *cardRepo) LockColumn(ctx context.Context, status string) error {
+	return conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "board:"+status).Error
+}
+
+func (r *cardRepo) Rank(ctx context.Context, status string, id uuid.UUID) (string, error) {
+	var card entities.Card
+	if err := conn(ctx, r.db).Select("rank").
+		Where("id = ? AND status_code = ? AND is_active = TRUE", id, status).
+		First(&card).Error; err != nil {
+		return "", err
+	}
+	return card.Rank, nil
+}
+
+func (r *cardRepo) AdjacentRank(ctx context.Context, status, rank string, id, exclude uuid.UUID, after bool) (string, error) {
+	qb := conn(ctx, r.db).Model(&entities.Card{}).
+		Where("status_code = ? AND is_active = TRUE AND id <> ?", status, exclude)
+	if after {
+		qb = qb.Where("(rank, id) > (?, ?)", rank, id).Order("rank asc, id asc")
+	} else {
+		qb = qb.Where("(rank, id) < (?, ?)", rank, id).Order("rank desc, id desc")
+	}
+	var ranks []string
+	if err := qb.Limit(1).Pluck("rank", &ranks).Error; err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+func (r *cardRepo) LastRank(ctx context.Context, status string, exclude uuid.UUID) (string, error) {
+	var ranks []string
+	if err := conn(ctx, r.db).Model(&entities.Card{}).
+		Where("status_code = ? AND is_active = TRUE AND id <> ?", status, exclude).
+		Order("rank desc, id desc").
+		Limit(1).
+		Pluck("rank", &ranks).Error; err != nil || len(ranks) == 0 {
+		return "", err
+	}
+	return ranks[0], nil
+}
+
+func (r *cardRepo) Rebalance(ctx context.Context, status string) error {
+	// same format as rank.Spread
+	return conn(ctx, r.db).Exec(`UPDATE cards c SET rank = lpad(to_hex(o.rn), 8, '0') || 'i'
+		FROM (SELECT id, row_number() OVER (ORDER BY rank, id) AS rn FROM cards WHERE status_code = ? AND is_active = TRUE) o
+		WHERE c.id = o.id`, status).Error
+}
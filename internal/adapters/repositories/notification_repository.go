@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	// DueReminders returns active, unfinished cards starting in (from, to] without a kind notification for their current scheduled_at
+	DueReminders(ctx context.Context, kind string, from, to time.Time, limit int) ([]*entities.Card, error)
+	// StaleCards returns active cards in status untouched since before, without a kind notification for their current updated_at
+	StaleCards(ctx context.Context, kind, status string, before time.Time, limit int) ([]*entities.Card, error)
+	Users(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error)
+	MarkSent(ctx context.Context, n *entities.CardNotification) error
+}
+
+type notificationRepo struct{ db *gorm.DB }
+
+func NewNotificationRepo(db *gorm.DB) NotificationRepository { return &notificationRepo{db} }
+
+func (r *notificationRepo) DueReminders(ctx context.Context, kind string, from, to time.Time, limit int) ([]*entities.Card, error) {
+	var list []*entities.Card
+	if err := r.db.WithContext(ctx).
+		Preload("Interviewers").
+		Where("is_active = TRUE AND status_code <> 'done'").
+		Where("scheduled_at > ? AND scheduled_at <= ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM card_notifications n WHERE n.card_id = cards.id AND n.kind = ? AND n.ref_time = cards.scheduled_at)", kind).
+		Order("scheduled_at asc").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *notificationRepo) StaleCards(ctx context.Context, kind, status string, before time.Time, limit int) ([]*entities.Card, error) {
+	var list []*entities.Card
+	if err := r.db.WithContext(ctx).
+		Preload("Interviewers").
+		Where("is_active = TRUE AND status_code = ?", status).
+		Where("updated_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM card_notifications n WHERE n.card_id = cards.id AND n.kind = ? AND n.ref_time = cards.updated_at)", kind).
+		Order("updated_at asc").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *notificationRepo) Users(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	var users []*entities.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ? AND is_active = TRUE", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// MarkSent ignores duplicates: another replica may have recorded the same notification
+func (r *notificationRepo) MarkSent(ctx context.Context, n *entities.CardNotification) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(n).Error
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"interview-tracker/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	// Add appends an event; call it inside the transaction that makes the change it describes
+	Add(ctx context.Context, e *entities.OutboxEvent) error
+	// TryLock takes the relay lock until the current transaction ends; false if another relay holds it
+	TryLock(ctx context.Context) (bool, error)
+	// Pending returns unpublished events due at now, oldest first. Cards with an earlier event
+	// still waiting for a retry are left out, so events are never published out of order.
+	Pending(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error)
+	Save(ctx context.Context, e *entities.OutboxEvent) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepo struct{ db *gorm.DB }
+
+func NewOutboxRepo(db *gorm.DB) OutboxRepository { return &outboxRepo{db} }
+
+func (r *outboxRepo) Add(ctx context.Context, e *entities.OutboxEvent) error {
+	db := conn(ctx, r.db)
+	// serializes writers of the same card until commit, so ids follow commit order per card
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "outbox:"+e.CardID.String()).Error; err != nil {
+		return err
+	}
+	return db.Create(e).Error
+}
+
+func (r *outboxRepo) TryLock(ctx context.Context) (bool, error) {
+	var ok bool
+	err := conn(ctx, r.db).Raw("SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))").Scan(&ok).Error
+	return ok, err
+}
+
+func (r *outboxRepo) Pending(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	var list []*entities.OutboxEvent
+	if err := conn(ctx, r.db).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Where("NOT EXISTS (SELECT 1 FROM outbox p WHERE p.card_id = outbox.card_id AND p.published_at IS NULL AND p.id < outbox.id AND p.next_attempt_at > ?)", now).
+		Order("id asc").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *outboxRepo) Save(ctx context.Context, e *entities.OutboxEvent) error {
+	return conn(ctx, r.db).Model(e).
+		Select("attempts", "next_attempt_at", "last_error", "sinks_done", "published_at").
+		Updates(e).Error
+}
+
+func (r *outboxRepo) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	res := conn(ctx, r.db).Where("published_at < ?", before).Delete(&entities.OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"interview-tracker/internal/entities"
 
 	_ "github.com/lib/pq"
@@ -8,17 +9,19 @@ import (
 )
 
 type RoleRepository interface {
-	GetList() ([]*entities.Role, error)
-	ExistsByID(id string) (bool, error)
+	GetList(ctx context.Context) ([]*entities.Role, error)
+	ExistsByID(ctx context.Context, id string) (bool, error)
+	GetByCode(ctx context.Context, code string) (*entities.Role, error)
+	GetPermissionList(ctx context.Context) ([]*entities.Permission, error)
 }
 
 type roleRepo struct{ db *gorm.DB }
 
 func NewRoleRepo(db *gorm.DB) RoleRepository { return &roleRepo{db} }
 
-func (r *roleRepo) GetList() ([]*entities.Role, error) {
+func (r *roleRepo) GetList(ctx context.Context) ([]*entities.Role, error) {
 	var roles []*entities.Role
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Model(&entities.Role{}).
 		Order("created_at ASC").
 		Find(&roles).Error; err != nil {
@@ -28,10 +31,29 @@ func (r *roleRepo) GetList() ([]*entities.Role, error) {
 	return roles, nil
 }
 
-func (r *roleRepo) ExistsByID(id string) (bool, error) {
+func (r *roleRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
 	var cnt int64
-	if err := r.db.Model(&entities.Role{}).Where("id = ? AND is_active = TRUE", id).Count(&cnt).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&entities.Role{}).Where("id = ? AND is_active = TRUE", id).Count(&cnt).Error; err != nil {
 		return false, err
 	}
 	return cnt > 0, nil
 }
+
+func (r *roleRepo) GetByCode(ctx context.Context, code string) (*entities.Role, error) {
+	var role entities.Role
+	if err := r.db.WithContext(ctx).First(&role, "code = ? AND is_active = TRUE", code).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepo) GetPermissionList(ctx context.Context) ([]*entities.Permission, error) {
+	var perms []*entities.Permission
+	if err := r.db.WithContext(ctx).
+		Where("is_active = TRUE").
+		Order("code ASC").
+		Find(&perms).Error; err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor runs fn in one database transaction. Repositories called with the ctx passed
+// to fn join that transaction, so a usecase can commit writes from several repositories
+// together. Nested calls become savepoints.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct{ db *gorm.DB }
+
+func NewTransactor(db *gorm.DB) Transactor { return &transactor{db} }
+
+type txKey struct{}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db when there is none
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -1,41 +1,46 @@
 package repositories
 
 import (
+	"context"
 	"interview-tracker/internal/entities"
+	"time"
 
 	_ "github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	Create(user entities.User) (*entities.User, error)
-	GetById(id string) (*entities.User, error)
-	GetByEmail(email string) (*entities.User, error)
-	GetPermissionsByUserID(userID string) ([]string, error)
+	Create(ctx context.Context, user entities.User) (*entities.User, error)
+	GetById(ctx context.Context, id string) (*entities.User, error)
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	// FindByEmail also returns a deactivated user, unlike GetByEmail
+	FindByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetPermissionsByUserID(ctx context.Context, userID string) ([]string, error)
+	UpdateRole(ctx context.Context, userID, roleID string) error
 }
 
 type userRepo struct{ db *gorm.DB }
 
 func NewUserRepo(db *gorm.DB) UserRepository { return &userRepo{db} }
 
-func (r *userRepo) Create(user entities.User) (*entities.User, error) {
-	if err := r.db.Create(&user).Error; err != nil {
+func (r *userRepo) Create(ctx context.Context, user entities.User) (*entities.User, error) {
+	if err := r.db.WithContext(ctx).Create(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepo) GetById(id string) (*entities.User, error) {
+func (r *userRepo) GetById(ctx context.Context, id string) (*entities.User, error) {
 	var user entities.User
-	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepo) GetByEmail(email string) (*entities.User, error) {
+func (r *userRepo) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var u entities.User
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Preload("Role").
 		First(&u, "email = ? AND is_active = true", email).Error; err != nil {
 		return nil, err
@@ -43,7 +48,17 @@ func (r *userRepo) GetByEmail(email string) (*entities.User, error) {
 	return &u, nil
 }
 
-func (r *userRepo) GetPermissionsByUserID(userID string) ([]string, error) {
+func (r *userRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	var u entities.User
+	if err := r.db.WithContext(ctx).
+		Preload("Role").
+		First(&u, "email = ?", email).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepo) GetPermissionsByUserID(ctx context.Context, userID string) ([]string, error) {
 	type row struct{ Code string }
 	var rows []row
 	q := `
@@ -54,7 +69,7 @@ func (r *userRepo) GetPermissionsByUserID(userID string) ([]string, error) {
 			JOIN permissions p ON p.id = rp.permission_id AND p.is_active = true
 			WHERE u.id = ? AND u.is_active = true
 		`
-	if err := r.db.Raw(q, userID).Scan(&rows).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(q, userID).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
 	perms := make([]string, 0, len(rows))
@@ -63,3 +78,12 @@ func (r *userRepo) GetPermissionsByUserID(userID string) ([]string, error) {
 	}
 	return perms, nil
 }
+
+func (r *userRepo) UpdateRole(ctx context.Context, userID, roleID string) error {
+	return r.db.WithContext(ctx).Model(&entities.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"role_id":    roleID,
+			"updated_at": time.Now(),
+		}).Error
+}
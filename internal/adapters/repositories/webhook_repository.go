@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, w *entities.Webhook) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Webhook, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Webhook, error)
+	List(ctx context.Context) ([]*entities.Webhook, error)
+	Update(ctx context.Context, w *entities.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ActiveFor returns the enabled webhooks subscribed to eventType
+	ActiveFor(ctx context.Context, eventType string) ([]*entities.Webhook, error)
+
+	// CreateDeliveries skips a delivery of an event the webhook already has (replays excepted)
+	CreateDeliveries(ctx context.Context, list []*entities.WebhookDelivery) error
+	// ClaimDue returns up to limit pending deliveries of enabled webhooks that are due at now,
+	// pushing their next_attempt_at to now+lease so a crashed dispatcher retries them later.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, d *entities.WebhookDelivery) error
+	// RecordResult resets the webhook's failure count on success; otherwise it increments it and
+	// disables the webhook once the count reaches disableAfter. It reports whether it disabled it.
+	RecordResult(ctx context.Context, webhookID uuid.UUID, ok bool, disableAfter int, reason string) (bool, error)
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, size int) ([]*entities.WebhookDelivery, int64, error)
+	GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*entities.WebhookDelivery, error)
+}
+
+type webhookRepo struct{ db *gorm.DB }
+
+func NewWebhookRepo(db *gorm.DB) WebhookRepository { return &webhookRepo{db} }
+
+func (r *webhookRepo) Create(ctx context.Context, w *entities.Webhook) error {
+	return conn(ctx, r.db).Create(w).Error
+}
+
+func (r *webhookRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.Webhook, error) {
+	var w entities.Webhook
+	if err := conn(ctx, r.db).First(&w, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *webhookRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Webhook, error) {
+	var list []*entities.Webhook
+	if len(ids) == 0 {
+		return list, nil
+	}
+	if err := conn(ctx, r.db).Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *webhookRepo) List(ctx context.Context) ([]*entities.Webhook, error) {
+	var list []*entities.Webhook
+	if err := conn(ctx, r.db).Order("created_at desc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *webhookRepo) Update(ctx context.Context, w *entities.Webhook) error {
+	return conn(ctx, r.db).Save(w).Error
+}
+
+// Delete removes the webhook together with its delivery log
+func (r *webhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&entities.Webhook{}, "id = ?", id).Error
+}
+
+func (r *webhookRepo) ActiveFor(ctx context.Context, eventType string) ([]*entities.Webhook, error) {
+	var list []*entities.Webhook
+	if err := conn(ctx, r.db).
+		Where("is_active = TRUE AND (? = ANY(events) OR '*' = ANY(events))", eventType).
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *webhookRepo) CreateDeliveries(ctx context.Context, list []*entities.WebhookDelivery) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&list).Error
+}
+
+func (r *webhookRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.WebhookDelivery, error) {
+	var list []*entities.WebhookDelivery
+	err := conn(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id AND w.is_active = TRUE
+			WHERE d.status = ? AND d.next_attempt_at <= ?
+			ORDER BY d.next_attempt_at
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, entities.DeliveryPending, now, limit).
+		Scan(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *webhookRepo) SaveAttempt(ctx context.Context, d *entities.WebhookDelivery) error {
+	return conn(ctx, r.db).Model(d).
+		Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at").
+		Updates(d).Error
+}
+
+func (r *webhookRepo) RecordResult(ctx context.Context, webhookID uuid.UUID, ok bool, disableAfter int, reason string) (bool, error) {
+	db := conn(ctx, r.db).Model(&entities.Webhook{}).Where("id = ?", webhookID)
+	if ok {
+		return false, db.Where("failure_count > 0").UpdateColumn("failure_count", 0).Error
+	}
+	if err := db.UpdateColumn("failure_count", gorm.Expr("failure_count + 1")).Error; err != nil {
+		return false, err
+	}
+	// a separate statement so only the update that crosses the threshold disables it
+	now := time.Now()
+	res := conn(ctx, r.db).Model(&entities.Webhook{}).
+		Where("id = ? AND is_active = TRUE AND failure_count >= ?", webhookID, disableAfter).
+		Updates(map[string]interface{}{
+			"is_active":       false,
+			"disabled_at":     now,
+			"disabled_reason": reason,
+			"updated_at":      now,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, size int) ([]*entities.WebhookDelivery, int64, error) {
+	var list []*entities.WebhookDelivery
+	var total int64
+	qb := conn(ctx, r.db).Model(&entities.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		qb = qb.Where("status = ?", status)
+	}
+	if err := qb.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := qb.Order("created_at desc").Limit(size).Offset((page - 1) * size).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *webhookRepo) GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	var d entities.WebhookDelivery
+	if err := conn(ctx, r.db).First(&d, "id = ? AND webhook_id = ?", id, webhookID).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -2,57 +2,645 @@ package config
 
 import (
 	"crypto/rsa"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
+	"net/netip"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/storage"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
 )
 
 var EnvConfig *Config
 
+// Config is the single source of settings for every subsystem.
+// It is loaded once by LoadConfig; nothing else should read the environment.
 type Config struct {
-	DatabaseUrl      string
-	HttpPort         string
-	RedisAddr        string
-	JWTSecret        string
-	AccessTTLMinutes int
-	RefreshTTLDays   int
-	JWTKeys          JWTKeys
+	AppEnv         string
+	GlobalEndpoint string
+	HttpPort       string
+	MetricsPort    string // separate listener for /metrics, kept off the public port; empty = disabled
+	PublicBaseURL  string // how clients reach the service; used to build calendar feed URLs
+	Server         ServerConfig
+	// HealthCheckTimeout bounds each readiness dependency check
+	HealthCheckTimeout time.Duration
+	Database           DatabaseConfig
+	Redis              RedisConfig
+	JWTSecret          string `json:"-"`
+	AccessTTL          time.Duration
+	RefreshTTL         time.Duration
+	JWTKeyFiles        JWTKeyFiles
+	JWTKeys            JWTKeys `json:"-"`
+	OIDC               OIDCConfig
+	RateLimit          RateLimitConfig
+	LoginLockout       LoginLockoutConfig
+	Proxy              ProxyConfig
+	CORS               CORSConfig
+	Tracing            TracingConfig
+	Log                LogConfig
+	Scheduler          SchedulerConfig
+	Webhook            WebhookConfig
+	Outbox             OutboxConfig
+	Realtime           RealtimeConfig
+	Attachment         AttachmentConfig
+	// DefaultLanguage is used when Accept-Language is missing or unsupported (th or en)
+	DefaultLanguage string
+}
+
+// ServerConfig tunes the http.Server and how long shutdown may take to drain.
+type ServerConfig struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+}
+
+type DatabaseConfig struct {
+	Url             string
+	MigrationsPath  string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+type JWTKeyFiles struct {
+	PrivatePath string
+	PublicPath  string
+}
+
+// OIDCConfig holds the settings for single sign-on through an external IdP.
+// SSO is disabled when IssuerURL is empty.
+type OIDCConfig struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	GroupsClaim   string
+	RoleMapping   map[string]string // IdP group -> roles.code
+	RolePriority  []string          // role codes, highest privilege first; decides between several mapped groups
+	DefaultRole   string
+	AutoProvision bool
+	SyncRole      bool // overwrite an existing user's role from its groups on every login
+	TrustEmail    bool // accept emails the IdP does not mark email_verified (only for an IdP that verifies them all)
+}
+
+func (o OIDCConfig) Enabled() bool { return o.IssuerURL != "" && o.ClientID != "" }
+
+// RatePolicy allows Limit requests per Window with up to Burst requests at once.
+type RatePolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Burst  int
+}
+
+// RateLimitConfig selects a policy per route ("METHOD /full/path" -> policy name);
+// routes without an entry use the "default" policy.
+type RateLimitConfig struct {
+	Policies map[string]RatePolicy
+	Routes   map[string]string
+}
+
+// LoginLockoutConfig locks password login for one email from a client IP for Duration once
+// MaxFailures logins for it have failed within Window. MaxFailures 0 turns the lockout off;
+// it defaults to 10 when TRUSTED_PROXIES or TRUSTED_PLATFORM is set and 0 otherwise.
+type LoginLockoutConfig struct {
+	MaxFailures int
+	Window      time.Duration
+	Duration    time.Duration
+}
+
+// ProxyConfig controls which peers may set the client IP through forwarding headers.
+// With no trusted proxies the TCP remote address is always used.
+type ProxyConfig struct {
+	TrustedProxies  []string // CIDRs or IPs
+	RealIPHeaders   []string // checked in order, e.g. X-Forwarded-For, X-Real-IP
+	TrustedPlatform string   // e.g. CF-Connecting-IP behind Cloudflare
+}
+
+type CORSConfig struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// LogConfig controls the structured logger and what the request log may contain.
+type LogConfig struct {
+	Level         string // debug, info, warn, error
+	Format        string // json, text
+	RedactHeaders []string
+	RedactFields  []string // JSON body fields and query parameters
+	LogBodies     bool
+	MaxBodyBytes  int
+}
+
+// TracingConfig configures OpenTelemetry export over OTLP/HTTP.
+// SampleRatio applies to new traces; incoming sampled parents are always followed.
+type TracingConfig struct {
+	Enabled     bool
+	ServiceName string
+	Endpoint    string // host:port of the collector, e.g. localhost:4318
+	Insecure    bool
+	SampleRatio float64
+}
+
+// SchedulerConfig drives the background jobs: interview reminders and stale-card alerts.
+type SchedulerConfig struct {
+	Enabled          bool // the reminder jobs only; outbox and webhook jobs always run
+	ReminderInterval time.Duration
+	StaleInterval    time.Duration
+	ReminderLeads    []time.Duration          // remind this long before scheduled_at, e.g. 24h,1h
+	StaleAfter       map[string]time.Duration // status -> alert after no change for this long
+	Notifier         string                   // log, file
+	NotifyFile       string                   // for the file notifier
+}
+
+// WebhookConfig controls outbound webhook delivery. A delivery is retried with exponential
+// backoff until MaxAttempts; a webhook is disabled after DisableAfter consecutive failures.
+// Non-public addresses are never dialled unless they are in AllowedNetworks.
+type WebhookConfig struct {
+	DispatchInterval time.Duration
+	Timeout          time.Duration // per request
+	MaxAttempts      int
+	DisableAfter     int
+	AllowedNetworks  []netip.Prefix // e.g. 127.0.0.1/32 for a receiver on the dev machine
+}
+
+// OutboxConfig controls the relay that publishes domain events from the outbox table.
+type OutboxConfig struct {
+	RelayInterval  time.Duration
+	RelayTimeout   time.Duration // limit of one relay run, independent of the interval
+	Sinks          []string      // webhook, realtime, redis, log; in the order they are called
+	RedisStream    string
+	RedisStreamMax int64         // approximate length the stream is trimmed to
+	Retention      time.Duration // published events are deleted after this long
+}
+
+// outboxSinks are the sink names OUTBOX_SINKS accepts
+var outboxSinks = map[string]bool{"webhook": true, "realtime": true, "redis": true, "log": true}
+
+// RealtimeConfig names the Redis Stream (and pub/sub channel) behind the board event stream.
+// The stream length bounds how far back a reconnecting client can resume.
+type RealtimeConfig struct {
+	Stream       string
+	StreamMaxLen int64
+}
+
+// AttachmentConfig: where card attachments are stored and what may be uploaded. The type is
+// detected from the content, not taken from the client.
+type AttachmentConfig struct {
+	Storage         storage.Config
+	MaxBytes        int64
+	AllowedTypes    []string
+	URLTTL          time.Duration // lifetime of download URLs
+	TransferTimeout time.Duration // replaces the server read/write timeouts for one upload or download
+}
+
+// attachment types accepted when ATTACHMENT_ALLOWED_TYPES is not set: documents, images and archives
+var defaultAttachmentTypes = []string{
+	"application/pdf",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.oasis.opendocument.text",
+	"text/plain",
+	"image/png",
+	"image/jpeg",
+	"application/zip",
+	"application/gzip",
 }
+
 type JWTKeys struct {
 	Private *rsa.PrivateKey
 	Public  *rsa.PublicKey
 }
 
-func LoadConfig() {
-	loadJWTKeys, err := LoadJWTKeys()
+var appEnvs = map[string]bool{"dev": true, "staging": true, "prod": true}
+
+// LoadConfig builds EnvConfig from flags, env and an optional config file
+// (see loader for precedence) and validates it. Any error should stop startup.
+func LoadConfig(args []string) error {
+	l, err := newLoader(args)
+	if err != nil {
+		return err
+	}
+
+	appEnv := l.str("APP_ENV", "dev")
+	httpPort := l.str("HTTP_PORT", "8080")
+	proxy := ProxyConfig{
+		TrustedProxies:  l.list("TRUSTED_PROXIES", nil),
+		RealIPHeaders:   l.list("REAL_IP_HEADERS", []string{"X-Forwarded-For", "X-Real-IP"}),
+		TrustedPlatform: l.str("TRUSTED_PLATFORM", ""),
+	}
+	// without trusted proxies every client behind an ingress or NAT shares one IP,
+	// so the lockout is only on by default once the real client IP can be resolved
+	lockoutFailures := 0
+	if len(proxy.TrustedProxies) > 0 || proxy.TrustedPlatform != "" {
+		lockoutFailures = 10
+	}
+	cfg := &Config{
+		AppEnv:         appEnv,
+		GlobalEndpoint: l.str("GLOBAL_ENDPOINT", "interview-tracker"),
+		HttpPort:       httpPort,
+		MetricsPort:    l.str("METRICS_PORT", "9091"),
+		PublicBaseURL:  strings.TrimRight(l.str("PUBLIC_BASE_URL", "http://localhost:"+httpPort), "/"),
+		Server: ServerConfig{
+			ReadTimeout:       l.duration("HTTP_READ_TIMEOUT", 15*time.Second, time.Second),
+			ReadHeaderTimeout: l.duration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second, time.Second),
+			WriteTimeout:      l.duration("HTTP_WRITE_TIMEOUT", 30*time.Second, time.Second),
+			IdleTimeout:       l.duration("HTTP_IDLE_TIMEOUT", 2*time.Minute, time.Second),
+			ShutdownTimeout:   l.duration("SHUTDOWN_TIMEOUT", 20*time.Second, time.Second),
+		},
+		HealthCheckTimeout: l.duration("HEALTH_CHECK_TIMEOUT", 2*time.Second, time.Second),
+		Database: DatabaseConfig{
+			Url:             l.str("DATABASE_URL", ""),
+			MigrationsPath:  l.str("MIGRATIONS_PATH", "file://migrations"),
+			MaxOpenConns:    l.integer("DB_MAX_OPEN_CONNS", 20),
+			MaxIdleConns:    l.integer("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: l.duration("DB_CONN_MAX_LIFETIME", 30*time.Minute, time.Second),
+		},
+		Redis: RedisConfig{
+			Addr:     l.str("REDIS_ADDR", ""),
+			Password: l.str("REDIS_PASSWORD", ""),
+			DB:       l.integer("REDIS_DB", 0),
+		},
+		JWTSecret: l.str("JWT_SECRET", ""),
+		// ACCESS_TTL/REFRESH_TTL take durations; the older *_MINUTES/*_DAYS keys still work
+		AccessTTL:  l.duration("ACCESS_TTL", l.duration("ACCESS_TTL_MINUTES", 15*time.Minute, time.Minute), time.Minute),
+		RefreshTTL: l.duration("REFRESH_TTL", l.duration("REFRESH_TTL_DAYS", 7*24*time.Hour, 24*time.Hour), 24*time.Hour),
+		JWTKeyFiles: JWTKeyFiles{
+			PrivatePath: l.str("JWT_PRIVATE_KEY_PEM", ""),
+			PublicPath:  l.str("JWT_PUBLIC_KEY_PEM", ""),
+		},
+		OIDC: OIDCConfig{
+			IssuerURL:     l.str("OIDC_ISSUER_URL", ""),
+			ClientID:      l.str("OIDC_CLIENT_ID", ""),
+			ClientSecret:  l.str("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:   l.str("OIDC_REDIRECT_URL", ""),
+			Scopes:        l.list("OIDC_SCOPES", []string{"openid", "email", "profile"}),
+			GroupsClaim:   l.str("OIDC_GROUPS_CLAIM", "groups"),
+			RoleMapping:   l.mapping("OIDC_ROLE_MAPPING"),
+			RolePriority:  l.list("OIDC_ROLE_PRIORITY", []string{"admin", "editor", "viewer"}),
+			DefaultRole:   l.str("OIDC_DEFAULT_ROLE", "viewer"),
+			AutoProvision: l.boolean("OIDC_AUTO_PROVISION", false),
+			SyncRole:      l.boolean("OIDC_SYNC_ROLE", true),
+			TrustEmail:    l.boolean("OIDC_TRUST_UNVERIFIED_EMAIL", false),
+		},
+		RateLimit: loadRateLimit(l),
+		LoginLockout: LoginLockoutConfig{
+			MaxFailures: l.integer("LOGIN_LOCKOUT_MAX_FAILURES", lockoutFailures),
+			Window:      l.duration("LOGIN_LOCKOUT_WINDOW", 15*time.Minute, time.Second),
+			Duration:    l.duration("LOGIN_LOCKOUT_DURATION", 15*time.Minute, time.Second),
+		},
+		Proxy: proxy,
+		CORS: CORSConfig{
+			AllowOrigins:     l.list("CORS_ALLOW_ORIGINS", defaultCORSOrigins[appEnv]),
+			AllowMethods:     l.list("CORS_ALLOW_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+			AllowHeaders:     l.list("CORS_ALLOW_HEADERS", []string{"Authorization", "Content-Type", "Accept", "Accept-Language", "If-Match", "If-None-Match", "X-API-Key", "X-Request-ID", "traceparent", "tracestate"}),
+			ExposeHeaders:    l.list("CORS_EXPOSE_HEADERS", []string{"ETag", "Link", "X-Total-Count", "X-Request-ID", "X-Trace-ID", "Retry-After", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"}),
+			AllowCredentials: l.boolean("CORS_ALLOW_CREDENTIALS", true),
+			MaxAge:           l.duration("CORS_MAX_AGE", 10*time.Minute, time.Second),
+		},
+		DefaultLanguage: l.str("DEFAULT_LANGUAGE", i18n.English),
+		Log: LogConfig{
+			Level:         l.str("LOG_LEVEL", "info"),
+			Format:        l.str("LOG_FORMAT", "json"),
+			RedactHeaders: l.list("LOG_REDACT_HEADERS", []string{"Authorization", "Cookie", "Set-Cookie", "X-API-Key", "Proxy-Authorization"}),
+			RedactFields:  l.list("LOG_REDACT_FIELDS", []string{"password", "new_password", "old_password", "refresh_token", "access_token", "id_token", "token", "secret", "client_secret", "api_key", "oauth_code", "oauth_state"}),
+			LogBodies:     l.boolean("LOG_REQUEST_BODY", true),
+			MaxBodyBytes:  l.integer("LOG_MAX_BODY_BYTES", 4096),
+		},
+		Scheduler: loadScheduler(l),
+		Webhook: WebhookConfig{
+			DispatchInterval: l.duration("WEBHOOK_DISPATCH_INTERVAL", 10*time.Second, time.Second),
+			Timeout:          l.duration("WEBHOOK_TIMEOUT", 10*time.Second, time.Second),
+			MaxAttempts:      l.integer("WEBHOOK_MAX_ATTEMPTS", 8),
+			DisableAfter:     l.integer("WEBHOOK_DISABLE_AFTER", 20),
+			AllowedNetworks:  l.prefixes("WEBHOOK_ALLOWED_NETWORKS"),
+		},
+		Outbox: OutboxConfig{
+			RelayInterval:  l.duration("OUTBOX_RELAY_INTERVAL", 5*time.Second, time.Second),
+			RelayTimeout:   l.duration("OUTBOX_RELAY_TIMEOUT", time.Minute, time.Second),
+			Sinks:          l.list("OUTBOX_SINKS", []string{"webhook", "realtime"}),
+			RedisStream:    l.str("OUTBOX_REDIS_STREAM", "interview-tracker:events"),
+			RedisStreamMax: int64(l.integer("OUTBOX_REDIS_STREAM_MAXLEN", 100000)),
+			Retention:      l.duration("OUTBOX_RETENTION", 7*24*time.Hour, time.Hour),
+		},
+		Realtime: RealtimeConfig{
+			Stream:       l.str("REALTIME_STREAM", "interview-tracker:board"),
+			StreamMaxLen: int64(l.integer("REALTIME_STREAM_MAXLEN", 10000)),
+		},
+		Attachment: AttachmentConfig{
+			Storage: storage.Config{
+				Driver:         l.str("STORAGE_DRIVER", "local"),
+				Dir:            l.str("STORAGE_LOCAL_DIR", "./data/attachments"),
+				Endpoint:       l.str("STORAGE_S3_ENDPOINT", ""),
+				PublicEndpoint: l.str("STORAGE_S3_PUBLIC_ENDPOINT", ""),
+				Region:         l.str("STORAGE_S3_REGION", ""),
+				Bucket:         l.str("STORAGE_S3_BUCKET", ""),
+				AccessKey:      l.str("STORAGE_S3_ACCESS_KEY", ""),
+				SecretKey:      l.str("STORAGE_S3_SECRET_KEY", ""),
+				UseSSL:         l.boolean("STORAGE_S3_USE_SSL", appEnv != "dev"),
+				PathStyle:      l.boolean("STORAGE_S3_PATH_STYLE", false),
+			},
+			MaxBytes:        int64(l.integer("ATTACHMENT_MAX_BYTES", 25<<20)),
+			AllowedTypes:    l.list("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+			URLTTL:          l.duration("ATTACHMENT_URL_TTL", 5*time.Minute, time.Second),
+			TransferTimeout: l.duration("ATTACHMENT_TRANSFER_TIMEOUT", 10*time.Minute, time.Second),
+		},
+		Tracing: TracingConfig{
+			Enabled:     l.boolean("TRACING_ENABLED", false),
+			ServiceName: l.str("OTEL_SERVICE_NAME", "interview-tracker"),
+			Endpoint:    l.str("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4318"),
+			Insecure:    l.boolean("OTEL_EXPORTER_OTLP_INSECURE", appEnv == "dev"),
+			SampleRatio: l.float("OTEL_TRACES_SAMPLE_RATIO", 1),
+		},
+	}
+
+	cfg.validate(l)
+	if len(l.errs) > 0 {
+		return fmt.Errorf("invalid config:\n  - %s", strings.Join(l.errs, "\n  - "))
+	}
+
+	keys, err := LoadJWTKeys(cfg.JWTKeyFiles)
+	if err != nil {
+		return fmt.Errorf("jwt keys: %w", err)
+	}
+	cfg.JWTKeys = *keys
+
+	EnvConfig = cfg
+	return nil
+}
+
+func (c *Config) validate(l *loader) {
+	if !appEnvs[c.AppEnv] {
+		l.fail("APP_ENV", "must be one of dev, staging, prod; got %q", c.AppEnv)
+	}
+	if _, err := strconv.Atoi(c.HttpPort); err != nil {
+		l.fail("HTTP_PORT", "invalid port %q", c.HttpPort)
+	}
+	if c.MetricsPort != "" {
+		if _, err := strconv.Atoi(c.MetricsPort); err != nil {
+			l.fail("METRICS_PORT", "invalid port %q", c.MetricsPort)
+		} else if c.MetricsPort == c.HttpPort {
+			l.fail("METRICS_PORT", "must differ from HTTP_PORT so metrics stay off the public listener")
+		}
+	}
+	if u, err := url.Parse(c.PublicBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		l.fail("PUBLIC_BASE_URL", "must be an absolute http(s) URL; got %q", c.PublicBaseURL)
+	}
+	// gin only parses these in SetTrustedProxies at startup, where a typo would panic
+	for _, p := range c.Proxy.TrustedProxies {
+		var err error
+		if strings.Contains(p, "/") {
+			_, err = netip.ParsePrefix(p)
+		} else {
+			_, err = netip.ParseAddr(p)
+		}
+		if err != nil {
+			l.fail("TRUSTED_PROXIES", "invalid IP or CIDR %q", p)
+		}
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		l.fail("SHUTDOWN_TIMEOUT", "must be positive")
+	}
+	if c.Database.Url == "" {
+		l.fail("DATABASE_URL", "required")
+	}
+	if c.Redis.Addr == "" {
+		l.fail("REDIS_ADDR", "required")
+	}
+	if c.AccessTTL <= 0 || c.RefreshTTL <= 0 {
+		l.fail("ACCESS_TTL/REFRESH_TTL", "must be positive")
+	}
+	if c.JWTKeyFiles.PrivatePath == "" && c.JWTKeyFiles.PublicPath == "" {
+		l.fail("JWT_PRIVATE_KEY_PEM", "required")
+	}
+	if c.OIDC.Enabled() && c.OIDC.RedirectURL == "" {
+		l.fail("OIDC_REDIRECT_URL", "required when OIDC_ISSUER_URL is set")
+	}
+	for group, code := range c.OIDC.RoleMapping {
+		if !slices.Contains(c.OIDC.RolePriority, code) {
+			l.fail("OIDC_ROLE_PRIORITY", "role %q mapped from group %q is not listed", code, group)
+		}
+	}
+	if _, ok := c.RateLimit.Policies["default"]; !ok {
+		l.fail("RATE_LIMIT_POLICIES", "a default policy is required")
+	}
+	for route, name := range c.RateLimit.Routes {
+		if _, ok := c.RateLimit.Policies[name]; !ok && name != RateLimitNone {
+			l.fail("RATE_LIMIT_ROUTES", "%s uses unknown policy %q", route, name)
+		}
+	}
+	if c.LoginLockout.MaxFailures < 0 {
+		l.fail("LOGIN_LOCKOUT_MAX_FAILURES", "must not be negative")
+	}
+	if c.LoginLockout.MaxFailures > 0 && (c.LoginLockout.Window < time.Second || c.LoginLockout.Duration < time.Second) {
+		l.fail("LOGIN_LOCKOUT_WINDOW/LOGIN_LOCKOUT_DURATION", "must be at least 1s")
+	}
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		l.fail("LOG_LEVEL", "%v", err)
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		l.fail("LOG_FORMAT", "must be json or text; got %q", c.Log.Format)
+	}
+	if !i18n.Supported(c.DefaultLanguage) {
+		l.fail("DEFAULT_LANGUAGE", "must be th or en; got %q", c.DefaultLanguage)
+	}
+	if c.Scheduler.Notifier != "log" && c.Scheduler.Notifier != "file" {
+		l.fail("NOTIFIER", "must be log or file; got %q", c.Scheduler.Notifier)
+	}
+	if c.Scheduler.Notifier == "file" && c.Scheduler.NotifyFile == "" {
+		l.fail("NOTIFY_FILE_PATH", "required when NOTIFIER=file")
+	}
+	if c.Scheduler.ReminderInterval < time.Minute || c.Scheduler.StaleInterval < time.Minute {
+		l.fail("SCHEDULER_REMINDER_INTERVAL/SCHEDULER_STALE_INTERVAL", "must be at least 1m")
+	}
+	for _, d := range c.Scheduler.ReminderLeads {
+		if d < time.Minute || d%time.Minute != 0 {
+			l.fail("REMINDER_LEAD_TIMES", "lead times must be whole minutes; got %s", d)
+		}
+	}
+	if c.Webhook.DispatchInterval < time.Second || c.Webhook.Timeout < time.Second {
+		l.fail("WEBHOOK_DISPATCH_INTERVAL/WEBHOOK_TIMEOUT", "must be at least 1s")
+	}
+	if c.Webhook.MaxAttempts < 1 || c.Webhook.DisableAfter < 1 {
+		l.fail("WEBHOOK_MAX_ATTEMPTS/WEBHOOK_DISABLE_AFTER", "must be at least 1")
+	}
+	for _, s := range c.Outbox.Sinks {
+		if !outboxSinks[s] {
+			l.fail("OUTBOX_SINKS", "unknown sink %q (webhook, realtime, redis, log)", s)
+		}
+	}
+	if c.Outbox.RelayInterval < time.Second || c.Outbox.RelayTimeout < time.Second || c.Outbox.Retention < time.Hour {
+		l.fail("OUTBOX_RELAY_INTERVAL/OUTBOX_RELAY_TIMEOUT/OUTBOX_RETENTION", "relay interval and timeout must be at least 1s and retention at least 1h")
+	}
+	if c.Outbox.RedisStream == "" || c.Outbox.RedisStreamMax < 1 {
+		l.fail("OUTBOX_REDIS_STREAM/OUTBOX_REDIS_STREAM_MAXLEN", "stream name required and max length must be positive")
+	}
+	if c.Realtime.Stream == "" || c.Realtime.StreamMaxLen < 1 {
+		l.fail("REALTIME_STREAM/REALTIME_STREAM_MAXLEN", "stream name required and max length must be positive")
+	}
+	if c.Realtime.Stream == c.Outbox.RedisStream {
+		l.fail("REALTIME_STREAM", "must differ from OUTBOX_REDIS_STREAM")
+	}
+	switch st := c.Attachment.Storage; st.Driver {
+	case "local":
+		if st.Dir == "" {
+			l.fail("STORAGE_LOCAL_DIR", "required when STORAGE_DRIVER=local")
+		}
+	case "s3":
+		if st.Endpoint == "" || st.Bucket == "" || st.AccessKey == "" || st.SecretKey == "" {
+			l.fail("STORAGE_S3_ENDPOINT/STORAGE_S3_BUCKET/STORAGE_S3_ACCESS_KEY/STORAGE_S3_SECRET_KEY", "required when STORAGE_DRIVER=s3")
+		}
+	default:
+		l.fail("STORAGE_DRIVER", "must be local or s3; got %q", st.Driver)
+	}
+	if c.Attachment.MaxBytes < 1 {
+		l.fail("ATTACHMENT_MAX_BYTES", "must be positive")
+	}
+	for _, t := range c.Attachment.AllowedTypes {
+		if _, _, err := mime.ParseMediaType(t); err != nil {
+			l.fail("ATTACHMENT_ALLOWED_TYPES", "invalid MIME type %q", t)
+		}
+	}
+	if c.Attachment.URLTTL < time.Second || c.Attachment.URLTTL > time.Hour {
+		l.fail("ATTACHMENT_URL_TTL", "must be between 1s and 1h")
+	}
+	if c.Attachment.TransferTimeout < time.Second {
+		l.fail("ATTACHMENT_TRANSFER_TIMEOUT", "must be at least 1s")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		l.fail("OTEL_TRACES_SAMPLE_RATIO", "must be between 0 and 1")
+	}
+	for _, o := range c.CORS.AllowOrigins {
+		if o == "*" && c.CORS.AllowCredentials {
+			l.fail("CORS_ALLOW_ORIGINS", "wildcard origin cannot be combined with credentials")
+		}
+	}
+}
+
+// Redacted returns the config as JSON with secrets masked, safe to log.
+func (c *Config) Redacted() string {
+	cp := *c
+	cp.Database.Url = redactURL(c.Database.Url)
+	if cp.Redis.Password != "" {
+		cp.Redis.Password = "***"
+	}
+	if cp.OIDC.ClientSecret != "" {
+		cp.OIDC.ClientSecret = "***"
+	}
+	b, _ := json.Marshal(cp)
+	return string(b)
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
 	if err != nil {
-		panic(err)
+		return "***"
+	}
+	return u.Redacted()
+}
+
+// origins allowed when CORS_ALLOW_ORIGINS is not set; prod must configure its own
+var defaultCORSOrigins = map[string][]string{
+	"dev":     {"http://localhost:3000", "http://localhost:5173"},
+	"staging": {},
+	"prod":    {},
+}
+
+var defaultRatePolicies = map[string]RatePolicy{
+	"default": {Name: "default", Limit: 60, Window: time.Minute, Burst: 20},
+	"login":   {Name: "login", Limit: 10, Window: time.Minute, Burst: 5},
+	"list":    {Name: "list", Limit: 300, Window: time.Minute, Burst: 50},
+}
+
+// RateLimitNone exempts a route from rate limiting (e.g. kubelet probes)
+const RateLimitNone = "none"
+
+var defaultRateRoutes = map[string]string{
+	"GET /interview-tracker/health":                        RateLimitNone,
+	"GET /interview-tracker/health/live":                   RateLimitNone,
+	"GET /interview-tracker/health/ready":                  RateLimitNone,
+	"POST /interview-tracker/internal/v1/auth/login":       "login",
+	"POST /interview-tracker/internal/v1/auth/refresh":     "login",
+	"GET /interview-tracker/internal/v1/auth/sso/login":    "login",
+	"GET /interview-tracker/internal/v1/auth/sso/callback": "login",
+	"GET /interview-tracker/authen/cards":                  "list",
+	"GET /interview-tracker/authen/cards/:id/comments":     "list",
+	"GET /interview-tracker/authen/cards/:id/history":      "list",
+}
+
+// loadRateLimit merges overrides on top of the defaults:
+//   - RATE_LIMIT_POLICIES="login=10/1m:5,list=300/1m:50" (name=limit/window:burst)
+//   - RATE_LIMIT_ROUTES="POST /interview-tracker/internal/v1/auth/login=login"
+func loadRateLimit(l *loader) RateLimitConfig {
+	cfg := RateLimitConfig{Policies: map[string]RatePolicy{}, Routes: map[string]string{}}
+	for k, v := range defaultRatePolicies {
+		cfg.Policies[k] = v
+	}
+	for k, v := range defaultRateRoutes {
+		cfg.Routes[k] = v
+	}
+	for name, spec := range l.mapping("RATE_LIMIT_POLICIES") {
+		p, err := parseRatePolicy(name, spec)
+		if err != nil {
+			l.fail("RATE_LIMIT_POLICIES", "%v", err)
+			continue
+		}
+		cfg.Policies[name] = p
 	}
-	EnvConfig = &Config{
-		DatabaseUrl:      os.Getenv("DATABASE_URL"),
-		HttpPort:         os.Getenv("HTTP_PORT"),
-		RedisAddr:        os.Getenv("REDIS_ADDR"),
-		JWTSecret:        os.Getenv("JWT_SECRET"),
-		AccessTTLMinutes: envAsInt("ACCESS_TTL_MINUTES", 15),
-		RefreshTTLDays:   envAsInt("REFRESH_TTL_DAYS", 7),
-		JWTKeys:          *loadJWTKeys,
+	for route, name := range l.mapping("RATE_LIMIT_ROUTES") {
+		cfg.Routes[route] = name
 	}
+	return cfg
 }
 
-func envAsInt(key string, def int) int {
-	if v := os.Getenv(key); v != "" {
-		if n, err := strconv.Atoi(v); err == nil {
-			return n
+func parseRatePolicy(name, spec string) (RatePolicy, error) {
+	p := RatePolicy{Name: name, Burst: 1}
+	rest, burst, hasBurst := strings.Cut(spec, ":")
+	limit, window, ok := strings.Cut(rest, "/")
+	if !ok {
+		return p, fmt.Errorf("rate limit policy %q: expected limit/window[:burst], got %q", name, spec)
+	}
+	var err error
+	if p.Limit, err = strconv.Atoi(limit); err != nil || p.Limit <= 0 {
+		return p, fmt.Errorf("rate limit policy %q: invalid limit %q", name, limit)
+	}
+	if p.Window, err = time.ParseDuration(window); err != nil || p.Window <= 0 {
+		return p, fmt.Errorf("rate limit policy %q: invalid window %q", name, window)
+	}
+	if hasBurst {
+		if p.Burst, err = strconv.Atoi(burst); err != nil || p.Burst < 1 {
+			return p, fmt.Errorf("rate limit policy %q: invalid burst %q", name, burst)
 		}
 	}
-	return def
+	return p, nil
 }
 
-func LoadJWTKeys() (*JWTKeys, error) {
-	privPath := os.Getenv("JWT_PRIVATE_KEY_PEM")
-	pubPath := os.Getenv("JWT_PUBLIC_KEY_PEM")
+func LoadJWTKeys(files JWTKeyFiles) (*JWTKeys, error) {
+	privPath := files.PrivatePath
+	pubPath := files.PublicPath
 
 	var (
 		priv *rsa.PrivateKey
@@ -90,3 +678,38 @@ func LoadJWTKeys() (*JWTKeys, error) {
 
 	return &JWTKeys{Private: priv, Public: pub}, nil
 }
+
+// loadScheduler reads the job settings:
+//   - REMINDER_LEAD_TIMES="24h,1h"
+//   - STALE_CARD_AFTER="todo=168h,in_progress=336h" (statuses not listed are never stale)
+func loadScheduler(l *loader) SchedulerConfig {
+	cfg := SchedulerConfig{
+		Enabled:          l.boolean("SCHEDULER_ENABLED", true),
+		ReminderInterval: l.duration("SCHEDULER_REMINDER_INTERVAL", 5*time.Minute, time.Second),
+		StaleInterval:    l.duration("SCHEDULER_STALE_INTERVAL", time.Hour, time.Second),
+		StaleAfter:       map[string]time.Duration{},
+		Notifier:         l.str("NOTIFIER", "log"),
+		NotifyFile:       l.str("NOTIFY_FILE_PATH", ""),
+	}
+	for _, v := range l.list("REMINDER_LEAD_TIMES", []string{"24h", "1h"}) {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			l.fail("REMINDER_LEAD_TIMES", "invalid duration %q", v)
+			continue
+		}
+		cfg.ReminderLeads = append(cfg.ReminderLeads, d)
+	}
+	stale := l.mapping("STALE_CARD_AFTER")
+	if _, ok := l.lookup("STALE_CARD_AFTER"); !ok {
+		stale = map[string]string{"todo": "168h", "in_progress": "336h"}
+	}
+	for status, v := range stale {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			l.fail("STALE_CARD_AFTER", "invalid duration %q for %s", v, status)
+			continue
+		}
+		cfg.StaleAfter[status] = d
+	}
+	return cfg
+}
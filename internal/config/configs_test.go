@@ -0,0 +1,73 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRatePolicy(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    RatePolicy
+		wantErr bool
+	}{
+		{spec: "60/1m:20", want: RatePolicy{Name: "p", Limit: 60, Window: time.Minute, Burst: 20}},
+		{spec: "10/30s", want: RatePolicy{Name: "p", Limit: 10, Window: 30 * time.Second, Burst: 1}},
+		{spec: "1/1h:1", want: RatePolicy{Name: "p", Limit: 1, Window: time.Hour, Burst: 1}},
+		{spec: "60", wantErr: true},
+		{spec: "60:5", wantErr: true},
+		{spec: "x/1m", wantErr: true},
+		{spec: "0/1m", wantErr: true},
+		{spec: "-1/1m", wantErr: true},
+		{spec: "60/1", wantErr: true},
+		{spec: "60/0s", wantErr: true},
+		{spec: "60/-1m", wantErr: true},
+		{spec: "60/1m:", wantErr: true},
+		{spec: "60/1m:0", wantErr: true},
+		{spec: "60/1m:x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseRatePolicy("p", tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRatePolicy(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseRatePolicy(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTrustedProxies(t *testing.T) {
+	tests := []struct {
+		proxies string
+		bad     []string
+	}{
+		{proxies: "10.0.0.0/8,192.168.1.1,fd00::/8,::1"},
+		{proxies: "10.0.0.0/8,10.0.0.300", bad: []string{"10.0.0.300"}},
+		{proxies: "10.0.0.0/33,ingress.local,192.168.1.1", bad: []string{"10.0.0.0/33", "ingress.local"}},
+		{proxies: "10.0.0.0/", bad: []string{"10.0.0.0/"}},
+	}
+	for _, tt := range tests {
+		l := testLoader(t, "", "-set", "TRUSTED_PROXIES="+tt.proxies)
+		c := &Config{Proxy: ProxyConfig{TrustedProxies: l.list("TRUSTED_PROXIES", nil)}}
+		c.validate(l)
+		var got []string
+		for _, e := range l.errs {
+			if strings.HasPrefix(e, "TRUSTED_PROXIES: ") {
+				got = append(got, e)
+			}
+		}
+		if len(got) != len(tt.bad) {
+			t.Errorf("TRUSTED_PROXIES=%s: errors %v, want %d", tt.proxies, got, len(tt.bad))
+			continue
+		}
+		for i, b := range tt.bad {
+			if !strings.Contains(got[i], strconv.Quote(b)) {
+				t.Errorf("TRUSTED_PROXIES=%s: error %q does not name %q", tt.proxies, got[i], b)
+			}
+		}
+	}
+}
@@ -2,25 +2,58 @@ package config
 
 import (
 	"interview-tracker/internal/pkg/logs"
-	"os"
+	"interview-tracker/internal/pkg/metrics"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
 var DB *gorm.DB
 
 func ConnectDB() {
 	// AutoMigrate
-	migrationDB()
+	mdb, err := migrationDB()
+	if err != nil {
+		panic(err)
+	}
+	_ = mdb.Close()
 
 	// ----------------------------Database Connection---------------------------------
-	logs.Logger.Printf("database| url: %s", os.Getenv("DATABASE_URL"))
-	db, err := gorm.Open(postgres.Open(os.Getenv("DATABASE_URL")), &gorm.Config{})
+	cfg := EnvConfig.Database
+	logs.Logger.Printf("database| url: %s", redactURL(cfg.Url))
+	// TranslateError turns unique violations into gorm.ErrDuplicatedKey for the usecases
+	db, err := gorm.Open(postgres.Open(cfg.Url), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic(err)
 	}
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		panic(err)
+	}
+	// one span per statement; metrics already come from GormPlugin
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics())); err != nil {
+		panic(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic(err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	logs.Logger.Printf("database| Connected to PostgreSQL successfully!")
 	DB = db
 }
+
+// CloseDB closes the GORM connection pool
+func CloseDB() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
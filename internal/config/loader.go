@@ -0,0 +1,221 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loader resolves a key with the precedence: flags (-set KEY=VALUE) > env > config file > default.
+// Keys are the same everywhere (the env var names), so a config file is a flat YAML map:
+//
+//	HTTP_PORT: 8080
+//	CORS_ALLOW_ORIGINS: [https://tracker.example.com]
+//	OIDC_ROLE_MAPPING: {tracker-admins: admin}
+//
+// Parse errors are collected instead of silently falling back to the default.
+type loader struct {
+	flags map[string]string
+	file  map[string]string
+	errs  []string
+}
+
+type setFlags map[string]string
+
+func (s setFlags) String() string { return "" }
+
+func (s setFlags) Set(v string) error {
+	k, val, ok := strings.Cut(v, "=")
+	if !ok || strings.TrimSpace(k) == "" {
+		return fmt.Errorf("expected KEY=VALUE, got %q", v)
+	}
+	s[strings.TrimSpace(k)] = val
+	return nil
+}
+
+func newLoader(args []string) (*loader, error) {
+	sets := setFlags{}
+	fs := flag.NewFlagSet("interview-tracker", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	fs.Var(sets, "set", "override a config key, e.g. -set HTTP_PORT=9090 (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	l := &loader{flags: sets, file: map[string]string{}}
+	if *configFile != "" {
+		b, err := os.ReadFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		raw := map[string]any{}
+		if err := yaml.Unmarshal(b, &raw); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", *configFile, err)
+		}
+		for k, v := range raw {
+			l.file[k] = flatten(v)
+		}
+	}
+	return l, nil
+}
+
+// flatten turns YAML sequences/maps into the same comma separated form used by env vars
+func flatten(v any) string {
+	switch x := v.(type) {
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			parts = append(parts, fmt.Sprint(e))
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		parts := make([]string, 0, len(x))
+		for k, e := range x {
+			parts = append(parts, k+"="+fmt.Sprint(e))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ",")
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func (l *loader) lookup(key string) (string, bool) {
+	if v, ok := l.flags[key]; ok {
+		return v, true
+	}
+	if v := os.Getenv(key); v != "" {
+		return v, true
+	}
+	if v, ok := l.file[key]; ok && v != "" {
+		return v, true
+	}
+	return "", false
+}
+
+func (l *loader) fail(key, format string, a ...any) {
+	l.errs = append(l.errs, key+": "+fmt.Sprintf(format, a...))
+}
+
+func (l *loader) str(key, def string) string {
+	if v, ok := l.lookup(key); ok {
+		return v
+	}
+	return def
+}
+
+func (l *loader) integer(key string, def int) int {
+	v, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		l.fail(key, "invalid integer %q", v)
+		return def
+	}
+	return n
+}
+
+func (l *loader) boolean(key string, def bool) bool {
+	v, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		l.fail(key, "invalid boolean %q", v)
+		return def
+	}
+	return b
+}
+
+func (l *loader) float(key string, def float64) float64 {
+	v, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		l.fail(key, "invalid number %q", v)
+		return def
+	}
+	return f
+}
+
+// duration accepts Go durations ("15m", "168h"); a bare number is read in unit
+func (l *loader) duration(key string, def, unit time.Duration) time.Duration {
+	v, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		return time.Duration(n) * unit
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		l.fail(key, "invalid duration %q", v)
+		return def
+	}
+	return d
+}
+
+// list splits a comma separated value, e.g. "openid,email,profile"
+func (l *loader) list(key string, def []string) []string {
+	v, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	out := []string{}
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// prefixes parses a list of CIDRs; a bare IP is taken as a single address
+func (l *loader) prefixes(key string) []netip.Prefix {
+	var out []netip.Prefix
+	for _, v := range l.list(key, nil) {
+		if !strings.Contains(v, "/") {
+			a, err := netip.ParseAddr(v)
+			if err != nil {
+				l.fail(key, "invalid IP or CIDR %q", v)
+				continue
+			}
+			out = append(out, netip.PrefixFrom(a, a.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(v)
+		if err != nil {
+			l.fail(key, "invalid IP or CIDR %q", v)
+			continue
+		}
+		out = append(out, p.Masked())
+	}
+	return out
+}
+
+// mapping parses "k1=v1,k2=v2" into a map
+func (l *loader) mapping(key string) map[string]string {
+	out := map[string]string{}
+	for _, pair := range l.list(key, nil) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			l.fail(key, "expected key=value, got %q", pair)
+			continue
+		}
+		out[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testLoader writes yaml to a config file (when not empty) and parses args after -config
+func testLoader(t *testing.T, yaml string, args ...string) *loader {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	if yaml != "" {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		args = append([]string{"-config", path}, args...)
+	}
+	l, err := newLoader(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLoaderPrecedence(t *testing.T) {
+	const key = "ITK_TEST_KEY"
+	tests := []struct {
+		name string
+		file string
+		env  string
+		flag string
+		want string
+	}{
+		{name: "default", want: "default"},
+		{name: "file", file: "from-file", want: "from-file"},
+		{name: "env over file", file: "from-file", env: "from-env", want: "from-env"},
+		{name: "flag over env", file: "from-file", env: "from-env", flag: "from-flag", want: "from-flag"},
+		{name: "flag over default", flag: "from-flag", want: "from-flag"},
+		{name: "empty env is unset", file: "from-file", env: "", want: "from-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(key, tt.env)
+			var yaml string
+			if tt.file != "" {
+				yaml = key + ": " + tt.file + "\n"
+			}
+			var args []string
+			if tt.flag != "" {
+				args = []string{"-set", key + "=" + tt.flag}
+			}
+			if got := testLoader(t, yaml, args...).str(key, "default"); got != tt.want {
+				t.Fatalf("str = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoaderFlagOverridesToEmpty(t *testing.T) {
+	t.Setenv("ITK_TEST_KEY", "from-env")
+	if got := testLoader(t, "", "-set", "ITK_TEST_KEY=").str("ITK_TEST_KEY", "default"); got != "" {
+		t.Fatalf("str = %q, want an explicit empty flag to win", got)
+	}
+}
+
+func TestLoaderFileValues(t *testing.T) {
+	l := testLoader(t, `
+ITK_PORT: 9090
+ITK_ON: true
+ITK_TTL: 15m
+ITK_TTL_SECONDS: 30
+ITK_ORIGINS:
+  - https://a.example
+  - https://b.example
+ITK_MAPPING:
+  admins: admin
+  editors: editor
+ITK_EMPTY:
+`)
+	if got := l.integer("ITK_PORT", 0); got != 9090 {
+		t.Errorf("integer = %d", got)
+	}
+	if got := l.boolean("ITK_ON", false); !got {
+		t.Errorf("boolean = %v", got)
+	}
+	if got := l.duration("ITK_TTL", 0, time.Second); got != 15*time.Minute {
+		t.Errorf("duration = %v", got)
+	}
+	if got := l.duration("ITK_TTL_SECONDS", 0, time.Second); got != 30*time.Second {
+		t.Errorf("bare number duration = %v", got)
+	}
+	if got := l.list("ITK_ORIGINS", nil); !reflect.DeepEqual(got, []string{"https://a.example", "https://b.example"}) {
+		t.Errorf("list = %v", got)
+	}
+	if got := l.mapping("ITK_MAPPING"); !reflect.DeepEqual(got, map[string]string{"admins": "admin", "editors": "editor"}) {
+		t.Errorf("mapping = %v", got)
+	}
+	if got := l.str("ITK_EMPTY", "default"); got != "default" {
+		t.Errorf("empty file value = %q, want the default", got)
+	}
+	if len(l.errs) != 0 {
+		t.Errorf("errors: %v", l.errs)
+	}
+}
+
+func TestLoaderCollectsErrors(t *testing.T) {
+	l := testLoader(t, "",
+		"-set", "ITK_INT=x",
+		"-set", "ITK_BOOL=maybe",
+		"-set", "ITK_DUR=soon",
+		"-set", "ITK_FLOAT=1,5",
+		"-set", "ITK_MAP=a=1,b",
+		"-set", "ITK_CIDRS=10.0.0.0/8,nope,1.2.3.4,10.0.0.0/99",
+	)
+	// invalid values fall back to the default and are reported
+	if got := l.integer("ITK_INT", 7); got != 7 {
+		t.Errorf("integer = %d, want the default", got)
+	}
+	if got := l.boolean("ITK_BOOL", true); !got {
+		t.Errorf("boolean = %v, want the default", got)
+	}
+	if got := l.duration("ITK_DUR", time.Minute, time.Second); got != time.Minute {
+		t.Errorf("duration = %v, want the default", got)
+	}
+	if got := l.float("ITK_FLOAT", 0.5); got != 0.5 {
+		t.Errorf("float = %v, want the default", got)
+	}
+	if got := l.mapping("ITK_MAP"); !reflect.DeepEqual(got, map[string]string{"a": "1"}) {
+		t.Errorf("mapping = %v", got)
+	}
+	if got := l.prefixes("ITK_CIDRS"); len(got) != 2 || got[1].String() != "1.2.3.4/32" {
+		t.Errorf("prefixes = %v", got)
+	}
+	want := []string{
+		`ITK_INT: invalid integer "x"`,
+		`ITK_BOOL: invalid boolean "maybe"`,
+		`ITK_DUR: invalid duration "soon"`,
+		`ITK_FLOAT: invalid number "1,5"`,
+		`ITK_MAP: expected key=value, got "b"`,
+		`ITK_CIDRS: invalid IP or CIDR "nope"`,
+		`ITK_CIDRS: invalid IP or CIDR "10.0.0.0/99"`,
+	}
+	if !reflect.DeepEqual(l.errs, want) {
+		t.Errorf("errs =\n%s\nwant\n%s", strings.Join(l.errs, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNewLoaderErrors(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	if _, err := newLoader([]string{"-set", "NOEQUALS"}); err == nil {
+		t.Error("-set without = accepted")
+	}
+	if _, err := newLoader([]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}); err == nil {
+		t.Error("missing config file accepted")
+	}
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	if err := os.WriteFile(bad, []byte("HTTP_PORT: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newLoader([]string{"-config", bad}); err == nil {
+		t.Error("malformed YAML accepted")
+	}
+}
+
+func TestLoadConfigReportsEveryError(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	err := LoadConfig([]string{
+		"-set", "APP_ENV=qa",
+		"-set", "HTTP_PORT=http",
+		"-set", "ACCESS_TTL=forever",
+		"-set", "LOG_FORMAT=xml",
+		"-set", "DATABASE_URL=",
+		"-set", "RATE_LIMIT_POLICIES=default=fast",
+	})
+	if err == nil {
+		t.Fatal("LoadConfig accepted an invalid config")
+	}
+	for _, want := range []string{"APP_ENV", "HTTP_PORT", "ACCESS_TTL: invalid duration", "LOG_FORMAT", "DATABASE_URL: required", "RATE_LIMIT_POLICIES"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error lacks %q:\n%v", want, err)
+		}
+	}
+}
@@ -2,48 +2,115 @@ package config
 
 import (
 	"bytes"
-	"encoding/json"
-	"fmt"
-	"interview-tracker/internal/pkg/logs"
 	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/pkg/logs"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
-func LogStartTimeMiddleware() gin.HandlerFunc {
+const RequestIDHeader = "X-Request-ID"
+
+// incoming ids are only propagated when they look sane; anything else gets a fresh uuid
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// skipped entirely: probes would drown the real traffic
+func quietPath(path string) bool {
+	return strings.HasPrefix(path, "/interview-tracker/health")
+}
+
+// RequestLogMiddleware gives every request an X-Request-ID (propagated from the caller
+// when valid), logs the request with sensitive headers/fields redacted, and logs the
+// response with status and latency once the handler chain has finished.
+func RequestLogMiddleware(cfg LogConfig) gin.HandlerFunc {
+	redactor := logs.NewRedactor(cfg.RedactHeaders, cfg.RedactFields)
+
 	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Set("requestID", id)
+		c.Request = c.Request.WithContext(logs.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
 
-		// before request
-		if c.FullPath() != "/interview-tracker/health" {
-			logApi(c)
+		if quietPath(c.Request.URL.Path) {
+			c.Next()
+			return
 		}
-		// after request
+
+		start := time.Now()
+		logRequest(c, cfg, redactor)
 		c.Next()
+
+		status := c.Writer.Status()
+		entry := logs.Ctx(c).WithFields(logrus.Fields{
+			"method":     c.Request.Method,
+			"route":      c.FullPath(),
+			"status":     status,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"bytes":      c.Writer.Size(),
+			"client_ip":  c.ClientIP(),
+		})
+		if len(c.Errors) > 0 {
+			entry = entry.WithField("errors", c.Errors.String())
+		}
+		switch {
+		case status >= 500:
+			entry.Error("response")
+		case status >= 400:
+			entry.Warn("response")
+		default:
+			entry.Info("response")
+		}
 	}
 }
 
-func logApi(c *gin.Context) {
-	logs.LoggerInfo(fmt.Sprintf("Request URL: %s %s", c.Request.Method, c.FullPath()))
-	headers, _ := json.Marshal(c.Request.Header)
-	logs.LoggerInfo(fmt.Sprintf("Headers: %s", string(headers)))
+// queryPrefix qualifies the generic "code"/"state" of the SSO callback as
+// oauth_code/oauth_state, so LOG_REDACT_FIELDS need not mask every "code" field
+func queryPrefix(route string) string {
+	if strings.HasSuffix(route, "/auth/sso/callback") {
+		return "oauth_"
+	}
+	return ""
+}
 
+func logRequest(c *gin.Context, cfg LogConfig, redactor *logs.Redactor) {
+	fields := logrus.Fields{
+		"method":    c.Request.Method,
+		"route":     c.FullPath(),
+		"path":      c.Request.URL.Path,
+		"client_ip": c.ClientIP(),
+		"headers":   redactor.Headers(c.Request.Header),
+	}
 	if len(c.Request.URL.Query()) > 0 {
-		queryParams, _ := json.Marshal(c.Request.URL.Query())
-		logs.LoggerInfo(fmt.Sprintf("Query Parameters: %s", string(queryParams)))
+		fields["query"] = redactor.Query(c.Request.URL.Query(), queryPrefix(c.FullPath()))
 	}
 
-	if c.Request.Body != nil {
-		bodyBytes, err := io.ReadAll(c.Request.Body)
-		if err == nil {
-			var formattedBody bytes.Buffer
-			if json.Compact(&formattedBody, bodyBytes) == nil {
-				logs.LoggerInfo(fmt.Sprintf("Request Body: %s", formattedBody.String()))
-			} else {
-				logs.LoggerInfo(fmt.Sprintf("Request Body (raw): %s", string(bodyBytes)))
-			}
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-		} else {
-			logs.LoggerInfo(fmt.Sprintf("Failed to read request body: %v", err))
+	// only JSON bodies are logged (never multipart uploads), capped at MaxBodyBytes
+	if cfg.LogBodies && c.Request.Body != nil && strings.HasPrefix(c.ContentType(), "application/json") {
+		// read one byte past the cap and hand the handler the peeked bytes + the rest of the stream
+		bodyBytes, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(cfg.MaxBodyBytes)+1))
+		if err != nil {
+			logs.Ctx(c).Warnf("failed to read request body: %v", err)
+		}
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(bodyBytes), c.Request.Body), c.Request.Body}
+		switch {
+		case len(bodyBytes) > cfg.MaxBodyBytes:
+			fields["body"] = "[body too large to log]"
+		case len(bodyBytes) > 0:
+			fields["body"] = redactor.JSON(bodyBytes)
 		}
 	}
+	logs.Ctx(c).WithFields(fields).Info("request")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
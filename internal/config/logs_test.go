@@ -0,0 +1,128 @@
+package config
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// logged runs one request through RequestLogMiddleware and returns the "request" log line
+// and the body the handler received
+func logged(t *testing.T, cfg LogConfig, req *http.Request) (logrus.Fields, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	l, hook := test.NewNullLogger()
+	prev := logs.Logger
+	logs.Logger = logrus.NewEntry(l)
+	t.Cleanup(func() { logs.Logger = prev })
+
+	var seen string
+	r := gin.New()
+	r.Use(RequestLogMiddleware(cfg))
+	handler := func(c *gin.Context) {
+		b, _ := io.ReadAll(c.Request.Body)
+		seen = string(b)
+		c.Status(http.StatusNoContent)
+	}
+	r.POST("/interview-tracker/internal/v1/auth/login", handler)
+	r.GET("/interview-tracker/internal/v1/auth/sso/callback", handler)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, e := range hook.AllEntries() {
+		if e.Message == "request" {
+			return e.Data, seen
+		}
+	}
+	t.Fatal("no request line logged")
+	return nil, ""
+}
+
+func TestRequestLogRedaction(t *testing.T) {
+	cfg := LogConfig{
+		RedactHeaders: []string{"Authorization"},
+		RedactFields:  []string{"password", "oauth_code", "oauth_state"},
+		LogBodies:     true,
+		MaxBodyBytes:  64,
+	}
+	const login = "/interview-tracker/internal/v1/auth/login"
+
+	t.Run("json body and headers", func(t *testing.T) {
+		body := `{"email":"a@b.c","password":"hunter2"}`
+		req := httptest.NewRequest(http.MethodPost, login, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer abc")
+		f, seen := logged(t, cfg, req)
+		if f["body"] != `{"email":"a@b.c","password":"[REDACTED]"}` {
+			t.Errorf("body = %v", f["body"])
+		}
+		if h := f["headers"].(map[string]string); h["Authorization"] != "[REDACTED]" {
+			t.Errorf("headers = %v", h)
+		}
+		if seen != body {
+			t.Errorf("handler read %q, want the original body", seen)
+		}
+	})
+
+	t.Run("oversized body", func(t *testing.T) {
+		body := `{"password":"hunter2","pad":"` + strings.Repeat("x", 100) + `"}`
+		req := httptest.NewRequest(http.MethodPost, login, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		f, seen := logged(t, cfg, req)
+		if f["body"] != "[body too large to log]" {
+			t.Errorf("body = %v", f["body"])
+		}
+		if seen != body {
+			t.Errorf("handler read %d bytes, want all %d", len(seen), len(body))
+		}
+	})
+
+	t.Run("non-json content type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, login, strings.NewReader("password=hunter2"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		f, seen := logged(t, cfg, req)
+		if _, ok := f["body"]; ok {
+			t.Errorf("body logged: %v", f["body"])
+		}
+		if seen != "password=hunter2" {
+			t.Errorf("handler read %q", seen)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, login, strings.NewReader(`password=hunter2`))
+		req.Header.Set("Content-Type", "application/json")
+		f, _ := logged(t, cfg, req)
+		if f["body"] != "[unparsable body omitted]" {
+			t.Errorf("body = %v", f["body"])
+		}
+	})
+
+	t.Run("sso callback query", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/interview-tracker/internal/v1/auth/sso/callback?code=abc&state=xyz&iss=i", nil)
+		f, _ := logged(t, cfg, req)
+		want := map[string]string{"code": "[REDACTED]", "state": "[REDACTED]", "iss": "i"}
+		q := f["query"].(map[string]string)
+		for k, v := range want {
+			if q[k] != v {
+				t.Errorf("query[%s] = %q, want %q", k, q[k], v)
+			}
+		}
+	})
+
+	t.Run("code on other routes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, login+"?code=abc", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		f, _ := logged(t, cfg, req)
+		if q := f["query"].(map[string]string); q["code"] != "abc" {
+			t.Errorf("query = %v", q)
+		}
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -14,7 +13,7 @@ import (
 
 func migrationDB() (*sql.DB, error) {
 	// Create a database connection
-	db, err := sql.Open("postgres", os.Getenv("DATABASE_URL"))
+	db, err := sql.Open("postgres", EnvConfig.Database.Url)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +32,7 @@ func migrationDB() (*sql.DB, error) {
 	}
 
 	// Specify the path to your migration files (relative to your project directory)
-	migrationPath := "file://migrations"
+	migrationPath := EnvConfig.Database.MigrationsPath
 
 	// Create a new migration instance
 	m, err := migrate.NewWithDatabaseInstance(
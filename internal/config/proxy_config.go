@@ -0,0 +1,21 @@
+package config
+
+import (
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrustedProxyConfig applies the trusted proxy settings so c.ClientIP() only honours
+// forwarding headers sent by known proxies.
+func TrustedProxyConfig(router *gin.Engine) {
+	cfg := EnvConfig.Proxy
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		panic(err)
+	}
+	router.ForwardedByClientIP = len(cfg.TrustedProxies) > 0
+	router.RemoteIPHeaders = cfg.RealIPHeaders
+	router.TrustedPlatform = cfg.TrustedPlatform
+
+	logs.Logger.Printf("proxy| trusted: %v headers: %v platform: %q", cfg.TrustedProxies, cfg.RealIPHeaders, cfg.TrustedPlatform)
+}
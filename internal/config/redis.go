@@ -3,15 +3,22 @@ package config
 import (
 	"context"
 	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 var Rdb *redis.Client
 
 func NewRedis() {
-	addr := EnvConfig.RedisAddr
-	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	cfg := EnvConfig.Redis
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.Addr, Password: cfg.Password, DB: cfg.DB})
+	rdb.AddHook(metrics.RedisHook{})
+	// one span per command (and per pipeline)
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		panic(err)
+	}
 	if err := rdb.Ping(context.Background()).Err(); err != nil {
 		panic(err)
 	}
@@ -19,3 +26,10 @@ func NewRedis() {
 	logs.Logger.Printf("redis| Connected successfully!")
 	Rdb = rdb
 }
+
+func CloseRedis() error {
+	if Rdb == nil {
+		return nil
+	}
+	return Rdb.Close()
+}
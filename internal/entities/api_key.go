@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ApiKeyPrefix marks a credential as an API key rather than a JWT
+const ApiKeyPrefix = "itk_"
+
+type ApiKey struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Name        string         `gorm:"not null" json:"name"`
+	Prefix      string         `gorm:"not null" json:"prefix"`
+	KeyHash     string         `gorm:"uniqueIndex;not null" json:"-"`
+	Permissions pq.StringArray `gorm:"type:text[]" json:"permissions" swaggertype:"array,string"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	LastUsedAt  *time.Time     `json:"last_used_at"`
+	RevokedAt   *time.Time     `json:"revoked_at"`
+	IsActive    bool           `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy   uuid.UUID      `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy   uuid.UUID      `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
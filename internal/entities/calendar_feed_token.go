@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CalendarFeedToken struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	Name       string     `gorm:"not null;default:''" json:"name"`
+	Prefix     string     `gorm:"not null" json:"prefix"`
+	TokenHash  string     `gorm:"uniqueIndex;not null" json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	IsActive   bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
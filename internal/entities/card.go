@@ -3,19 +3,58 @@ package entities
 import (
 	"time"
 
+	"interview-tracker/internal/pkg/markdown"
+
 	"github.com/google/uuid"
 )
 
+// DefaultTimeZone is used for cards created without a time_zone
+const DefaultTimeZone = "Asia/Bangkok"
+
+// DefaultDuration is the length of an interview created without ends_at, the same one
+// hour migration 000005 gave cards that predate the end time
+const DefaultDuration = time.Hour
+
+// ExcerptLength is how many characters of plain text list views get from a Markdown field
+const ExcerptLength = 200
+
 type Card struct {
 	ID            uuid.UUID `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Title         string    `json:"title"`
-	Description   string    `json:"description"`
+	Description   string    `json:"description"` // Markdown
 	CandidateName string    `json:"candidate_name"`
-	ScheduledAt   time.Time `json:"scheduled_at"`
+	Position      string    `json:"position" gorm:"not null;default:''"`
+	ScheduledAt   time.Time `json:"scheduled_at"` // start of the interview
+	EndsAt        time.Time `json:"ends_at" gorm:"not null"`
+	TimeZone      string    `json:"time_zone" gorm:"not null;default:'Asia/Bangkok'"` // IANA name
+	Location      string    `json:"location" gorm:"not null;default:''"`
+	MeetingURL    string    `json:"meeting_url" gorm:"not null;default:''"`
+	Sequence      int       `json:"sequence" gorm:"not null;default:0"` // iCalendar SEQUENCE, bumped on reschedule and archive
 	StatusCode    string    `json:"status_code" gorm:"not null"`
-	IsActive      bool      `gorm:"not null;default:true" json:"is_active"`
+	Rank          string    `json:"rank" gorm:"not null;default:''"`        // order within the status column, see pkg/rank
+	IsActive      bool      `gorm:"not null;default:true" json:"is_active"` // false once archived (keep)
 	CreatedBy     uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
 	UpdatedBy     uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
 	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Interviewers []CardInterviewer `gorm:"foreignKey:CardID" json:"interviewers"`
+
+	DescriptionHTML    string `gorm:"-" json:"description_html"`    // sanitized, set by Render
+	DescriptionExcerpt string `gorm:"-" json:"description_excerpt"` // plain text, set by Render
+}
+
+// Render sets DescriptionHTML and DescriptionExcerpt from the Markdown description
+func (c *Card) Render() {
+	c.DescriptionHTML = markdown.HTML(c.Description)
+	c.DescriptionExcerpt = markdown.Excerpt(c.Description, ExcerptLength)
+}
+
+// InterviewerIDs returns the user ids of the card's interviewers
+func (c *Card) InterviewerIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(c.Interviewers))
+	for _, i := range c.Interviewers {
+		ids = append(ids, i.UserID)
+	}
+	return ids
 }
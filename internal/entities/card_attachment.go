@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardAttachment is a file attached to a card; the content is in storage under StorageKey
+type CardAttachment struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CardID      uuid.UUID  `gorm:"type:uuid;not null" json:"card_id"`
+	StorageKey  string     `gorm:"not null" json:"-"`
+	Filename    string     `gorm:"not null" json:"filename"`
+	ContentType string     `gorm:"not null" json:"content_type"` // detected from the content
+	SizeBytes   int64      `gorm:"not null" json:"size_bytes"`
+	Checksum    string     `gorm:"not null" json:"checksum"` // SHA-256, hex
+	UploadedBy  *uuid.UUID `gorm:"type:uuid" json:"uploaded_by"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (CardAttachment) TableName() string { return "card_attachments" }
@@ -3,17 +3,88 @@ package entities
 import (
 	"time"
 
+	"interview-tracker/internal/pkg/markdown"
+
 	"github.com/google/uuid"
 )
 
 type CardComment struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CardID       string     `json:"card_id"`
+	ParentID     *uuid.UUID `gorm:"type:uuid" json:"parent_id"` // the thread's first comment; nil for a top-level comment
+	AuthorID     uuid.UUID  `json:"author_id"`
+	Content      string     `json:"content"` // Markdown
+	Edited       bool       `gorm:"not null;default:false" json:"edited"`
+	EditedAt     *time.Time `json:"edited_at"`
+	IsActive     bool       `gorm:"not null;default:true" json:"is_active"` // false once deleted
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy    *uuid.UUID `gorm:"type:uuid" json:"deleted_by,omitempty"`
+	DeleteReason string     `gorm:"not null;default:''" json:"delete_reason,omitempty"` // given by a moderator removing someone else's comment
+	CreatedBy    uuid.UUID  `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy    uuid.UUID  `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Mentions  []CommentMention  `gorm:"foreignKey:CommentID" json:"mentions"`
+	Reactions []ReactionSummary `gorm:"-" json:"reactions"`
+	Replies   []*CardComment    `gorm:"-" json:"replies,omitempty"` // threaded view only
+
+	ContentHTML string `gorm:"-" json:"content_html"` // sanitized, set by Render
+	Excerpt     string `gorm:"-" json:"excerpt"`      // plain text, set by Render
+}
+
+// Redact turns a deleted comment into the "comment removed" placeholder shown in threads:
+// only who deleted it and when are left
+func (c *CardComment) Redact() {
+	c.Content, c.ContentHTML, c.Excerpt, c.DeleteReason = "", "", "", ""
+	c.Mentions = []CommentMention{}
+	c.Reactions = []ReactionSummary{}
+}
+
+// Render sets ContentHTML and Excerpt from the Markdown content
+func (c *CardComment) Render() {
+	c.ContentHTML = markdown.HTML(c.Content)
+	c.Excerpt = markdown.Excerpt(c.Content, ExcerptLength)
+}
+
+// CommentEdit keeps the content a comment had before one edit
+type CommentEdit struct {
 	ID        uuid.UUID `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CardID    string    `json:"card_id"`
-	AuthorID  uuid.UUID `json:"author_id"`
-	Content   string    `json:"content"`
-	IsActive  bool      `gorm:"not null;default:true" json:"is_active"`
-	CreatedBy uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
-	UpdatedBy uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CommentID uuid.UUID `gorm:"type:uuid;not null" json:"comment_id"`
+	Content   string    `gorm:"not null" json:"content"`
+	EditedBy  uuid.UUID `gorm:"type:uuid" json:"edited_by"`
+	EditedAt  time.Time `gorm:"not null" json:"edited_at"`
+
+	ContentHTML string `gorm:"-" json:"content_html"` // sanitized, set by Render
+}
+
+func (e *CommentEdit) Render() { e.ContentHTML = markdown.HTML(e.Content) }
+
+func (CommentEdit) TableName() string { return "card_comment_edits" }
+
+// CommentMention is a user named with @handle in a comment
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Handle    string    `gorm:"not null" json:"handle"`
+	CreatedAt time.Time `gorm:"not null" json:"-"`
+}
+
+func (CommentMention) TableName() string { return "card_comment_mentions" }
+
+type CommentReaction struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"comment_id"`
+	Emoji     string    `gorm:"primaryKey" json:"emoji"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+}
+
+func (CommentReaction) TableName() string { return "card_comment_reactions" }
+
+// ReactionSummary is one emoji on a comment with who used it, oldest first
+type ReactionSummary struct {
+	Emoji   string      `json:"emoji"`
+	Count   int         `json:"count"`
+	UserIDs []uuid.UUID `json:"user_ids"`
+	Me      bool        `json:"me"` // the caller reacted with this emoji
 }
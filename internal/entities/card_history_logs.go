@@ -6,15 +6,26 @@ import (
 	"github.com/google/uuid"
 )
 
+// card history actions
+const (
+	HistoryCardCreated     = "card_created"
+	HistoryCardUpdated     = "card_updated"
+	HistoryStatusChanged   = "status_changed"
+	HistoryCommentDeleted  = "comment_deleted"
+	HistoryCommentRestored = "comment_restored"
+)
+
 type CardHistoryLogs struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CardID      uuid.UUID `json:"card_id"`
-	ActorID     uuid.UUID `json:"actor_id"`
-	Description string    `json:"description"`
-	StatusCode  string    `json:"status_code"`
-	IsActive    bool      `gorm:"not null;default:true" json:"is_active"`
-	CreatedBy   uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
-	UpdatedBy   uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CardID      uuid.UUID  `json:"card_id"`
+	ActorID     uuid.UUID  `json:"actor_id"`
+	Action      string     `gorm:"not null;default:''" json:"action"` // History* constants; empty for older entries
+	CommentID   *uuid.UUID `gorm:"type:uuid" json:"comment_id,omitempty"`
+	Description string     `json:"description"`
+	StatusCode  string     `json:"status_code"`
+	IsActive    bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy   uuid.UUID  `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
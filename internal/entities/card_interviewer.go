@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CardInterviewer struct {
+	CardID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
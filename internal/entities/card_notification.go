@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardNotification records a notification that was sent, so jobs never send it twice.
+// RefTime is the card's scheduled_at (reminders) or updated_at (stale alerts) at the time,
+// so a rescheduled or touched card can be notified again.
+type CardNotification struct {
+	ID      uuid.UUID `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CardID  uuid.UUID `gorm:"type:uuid;not null" json:"card_id"`
+	Kind    string    `gorm:"not null" json:"kind"`
+	RefTime time.Time `gorm:"not null" json:"ref_time"`
+	SentAt  time.Time `gorm:"not null" json:"sent_at"`
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "interview-tracker/internal/pkg/i18n"
+
+type CardStatus struct {
+	StatusCode    string `gorm:"primaryKey" json:"status_code"`
+	Name          string `gorm:"not null" json:"name"`
+	Description   string `json:"description"`
+	NameTh        string `gorm:"not null;default:''" json:"name_th"`
+	NameEn        string `gorm:"not null;default:''" json:"name_en"`
+	DescriptionTh string `gorm:"not null;default:''" json:"description_th"`
+	DescriptionEn string `gorm:"not null;default:''" json:"description_en"`
+}
+
+// Localize sets Name/Description to the lang translation (empty translations keep the original)
+func (s *CardStatus) Localize(lang string) {
+	s.Name, s.Description = localized(lang, s.Name, s.NameTh, s.NameEn, s.Description, s.DescriptionTh, s.DescriptionEn)
+}
+
+func localized(lang, name, nameTh, nameEn, desc, descTh, descEn string) (string, string) {
+	pick := func(fallback, th, en string) string {
+		v := en
+		if lang == i18n.Thai {
+			v = th
+		}
+		if v == "" {
+			return fallback
+		}
+		return v
+	}
+	return pick(name, nameTh, nameEn), pick(desc, descTh, descEn)
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes.
+// The relay publishes it to every sink afterwards; ID orders the events of one card.
+type OutboxEvent struct {
+	ID            int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID       uuid.UUID       `gorm:"type:uuid;uniqueIndex;not null" json:"event_id"`
+	CardID        uuid.UUID       `gorm:"type:uuid;not null" json:"card_id"`
+	EventType     string          `gorm:"not null" json:"event_type"`
+	ActorID       uuid.UUID       `gorm:"type:uuid" json:"actor_id"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null" json:"payload" swaggertype:"object"`
+	CreatedAt     time.Time       `gorm:"not null" json:"created_at"`
+	Attempts      int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time       `gorm:"not null" json:"next_attempt_at"`
+	LastError     string          `gorm:"not null;default:''" json:"last_error"`
+	SinksDone     pq.StringArray  `gorm:"type:text[];not null;default:'{}'" json:"sinks_done" swaggertype:"array,string"`
+	PublishedAt   *time.Time      `json:"published_at"`
+}
+
+func (OutboxEvent) TableName() string { return "outbox" }
@@ -3,13 +3,22 @@ package entities
 import "time"
 
 type Permission struct {
-	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	Code        string    `gorm:"uniqueIndex;not null" json:"code"`
-	Name        string    `gorm:"not null" json:"name"`
-	Description string    `gorm:"not null;default:''" json:"description"`
-	IsActive    bool      `gorm:"not null;default:true" json:"is_active"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Code        string `gorm:"uniqueIndex;not null" json:"code"`
+	Name        string `gorm:"not null" json:"name"`
+	Description string `gorm:"not null;default:''" json:"description"`
+	// translations; Name/Description are replaced by Localize for the request language
+	NameTh        string    `gorm:"not null;default:''" json:"name_th"`
+	NameEn        string    `gorm:"not null;default:''" json:"name_en"`
+	DescriptionTh string    `gorm:"not null;default:''" json:"description_th"`
+	DescriptionEn string    `gorm:"not null;default:''" json:"description_en"`
+	IsActive      bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Roles []Role `gorm:"many2many:role_permissions;" json:"-"`
 }
+
+func (p *Permission) Localize(lang string) {
+	p.Name, p.Description = localized(lang, p.Name, p.NameTh, p.NameEn, p.Description, p.DescriptionTh, p.DescriptionEn)
+}
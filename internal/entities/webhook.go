@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Webhook struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	URL            string         `gorm:"not null" json:"url"`
+	Description    string         `gorm:"not null;default:''" json:"description"`
+	Secret         string         `gorm:"not null" json:"-"`
+	Events         pq.StringArray `gorm:"type:text[];not null" json:"events" swaggertype:"array,string"`
+	FailureCount   int            `gorm:"not null;default:0" json:"failure_count"` // consecutive failed attempts
+	DisabledAt     *time.Time     `json:"disabled_at"`
+	DisabledReason string         `gorm:"not null;default:''" json:"disabled_reason"`
+	IsActive       bool           `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy      uuid.UUID      `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy      uuid.UUID      `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Subscribed reports whether the webhook wants events of type t ("*" subscribes to everything)
+func (w *Webhook) Subscribed(t string) bool {
+	for _, e := range w.Events {
+		if e == t || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed" // gave up after the last retry
+)
+
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WebhookID      uuid.UUID       `gorm:"type:uuid;not null" json:"webhook_id"`
+	EventID        uuid.UUID       `gorm:"type:uuid;not null" json:"event_id"`
+	EventType      string          `gorm:"not null" json:"event_type"`
+	Payload        json.RawMessage `gorm:"type:jsonb;not null" json:"payload" swaggertype:"object"`
+	Status         string          `gorm:"not null;default:pending" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time       `gorm:"not null" json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      string          `gorm:"not null;default:''" json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	ReplayedFrom   *uuid.UUID      `gorm:"type:uuid" json:"replayed_from,omitempty"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
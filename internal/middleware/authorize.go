@@ -1,17 +1,23 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/errs"
 	"interview-tracker/internal/pkg/logs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type AccessClaims struct {
@@ -25,24 +31,70 @@ type Session struct {
 	Perms  []string  `json:"perms"`
 }
 
-// core authn: validate JWT + redis session, return refID + session struct
+// ApiKeyAuthenticator resolves a raw API key to the key and its effective permissions.
+// Unknown, revoked or expired keys must give an *errs.HttpError with status 401.
+// Active re-checks a key already authenticated, for requests that stay open.
+type ApiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*entities.ApiKey, []string, error)
+	Active(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// apiKeys is set once by UseApiKeys while the routes are built
+var apiKeys ApiKeyAuthenticator
+
+// UseApiKeys sets how Authn/Authorize check API keys; call it before serving
+func UseApiKeys(a ApiKeyAuthenticator) { apiKeys = a }
+
+// core authn: validate JWT + redis session (or an API key), return refID + session struct.
+// The result is cached on the context so later middleware in the chain reuse it.
 func authenticate(c *gin.Context) (*Session, string, bool) {
-	logs.Logger.Println("[Authn] start...")
+	if s := GetSession(c); s != nil {
+		return s, c.GetString("refID"), true
+	}
+	s, refID, aerr := resolveSession(c)
+	if aerr != nil {
+		Fail(c, aerr)
+		return nil, "", false
+	}
+	return s, refID, true
+}
+
+// peekSession resolves the caller without aborting; for middleware that runs before Authn/Authorize
+func peekSession(c *gin.Context) *Session {
+	if s := GetSession(c); s != nil {
+		return s
+	}
+	if c.GetHeader("Authorization") == "" && c.GetHeader("X-API-Key") == "" {
+		return nil
+	}
+	s, _, aerr := resolveSession(c)
+	if aerr != nil {
+		return nil
+	}
+	return s
+}
+
+func resolveSession(c *gin.Context) (*Session, string, error) {
+	logs.Ctx(c).Debug("[Authn] start...")
+
+	// 0) service-to-service API key
+	if raw := apiKeyFromRequest(c); raw != "" {
+		return authenticateApiKey(c, raw)
+	}
 
 	// 1) header
 	auth := strings.TrimSpace(c.GetHeader("Authorization"))
 	parts := strings.Fields(auth)
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("missing bearer token").WithCode("missing_token")
 	}
 	tokenStr := parts[1]
 
 	// 2) public key
 	pub := config.EnvConfig.JWTKeys.Public
 	if pub == nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "public key not loaded"})
-		return nil, "", false
+		logs.Ctx(c).Error("[Authn] public key not loaded")
+		return nil, "", errs.Internal()
 	}
 
 	// 3) parse + verify
@@ -54,34 +106,29 @@ func authenticate(c *gin.Context) (*Session, string, bool) {
 		return pub, nil
 	})
 	if err != nil || !token.Valid {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("invalid token").WithCode("invalid_token")
 	}
 
 	// 4) issuer
 	if claims.Issuer != "interview-tracker" {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid issuer"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("invalid issuer").WithCode("invalid_token")
 	}
 
 	// 5) exp
 	if claims.ExpiresAt == nil || time.Now().After(claims.ExpiresAt.Time) {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token expired"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("token expired").WithCode("token_expired")
 	}
 
 	// 6) subject
 	refID := claims.Subject
 	if refID == "" {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing sub"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("missing sub").WithCode("invalid_token")
 	}
 
 	// 7) session in redis
 	val, err := config.Rdb.Get(c, "session:"+refID).Result()
 	if err != nil || val == "" {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session expired or revoked"})
-		return nil, "", false
+		return nil, "", errs.Unauthorized("session expired or revoked").WithCode("session_revoked")
 	}
 
 	var s Session
@@ -89,11 +136,75 @@ func authenticate(c *gin.Context) (*Session, string, bool) {
 
 	// inject to context
 	c.Set("refID", refID)
-	logs.Logger.Println(">>>>>>")
-	logs.LogJson(s)
+	logs.Ctx(c).WithFields(logrus.Fields{"user_id": s.UserID, "role": s.Role}).Debug("[Authn] session resolved")
 	c.Set("session", s)
 
-	return &s, refID, true
+	return &s, refID, nil
+}
+
+// API key ส่งมาได้ทาง X-API-Key หรือ Authorization: Bearer itk_...
+func apiKeyFromRequest(c *gin.Context) string {
+	if k := strings.TrimSpace(c.GetHeader("X-API-Key")); k != "" {
+		return k
+	}
+	parts := strings.Fields(c.GetHeader("Authorization"))
+	if len(parts) == 2 && strings.EqualFold(parts[0], "Bearer") && strings.HasPrefix(parts[1], entities.ApiKeyPrefix) {
+		return parts[1]
+	}
+	return ""
+}
+
+// authenticateApiKey builds a session for the key; actions are attributed to the user who issued it.
+// refID stays empty so session-only endpoints (logout) reject API keys.
+// Only a key that is unknown, revoked or expired is a 401; a failed lookup is a server error.
+func authenticateApiKey(c *gin.Context, raw string) (*Session, string, error) {
+	if apiKeys == nil {
+		logs.Ctx(c).Error("[Authn] api keys not configured")
+		return nil, "", errs.Internal()
+	}
+	key, perms, err := apiKeys.Authenticate(c, raw)
+	var he *errs.HttpError
+	if errors.As(err, &he) && he.Status == http.StatusUnauthorized {
+		logs.Ctx(c).Warnf("[Authn] api key rejected: %v", err)
+		return nil, "", errs.Unauthorized("invalid api key").WithCode("invalid_api_key")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	s := Session{UserID: key.CreatedBy, Email: "apikey:" + key.Name, Role: "api_key", Perms: perms}
+	c.Set("apiKeyID", key.ID.String())
+	c.Set("session", s)
+	return &s, "", nil
+}
+
+// TokenFromQuery accepts the access token as ?access_token= for clients that cannot set
+// headers (the browser EventSource). Add it only to the routes that need it, before Authorize;
+// the request log redacts access_token but proxies in front may not.
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if t := c.Query("access_token"); t != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+t)
+		}
+		c.Next()
+	}
+}
+
+// SessionActive reports whether the caller's login session, or the API key it used, is still
+// valid, for requests that stay open after authentication.
+func SessionActive(c *gin.Context) bool {
+	if id, err := uuid.Parse(c.GetString("apiKeyID")); err == nil && apiKeys != nil {
+		ok, err := apiKeys.Active(c, id)
+		// a database hiccup should not drop every open stream at once
+		return err != nil || ok
+	}
+	refID := c.GetString("refID")
+	if refID == "" {
+		return true
+	}
+	n, err := config.Rdb.Exists(c, "session:"+refID).Result()
+	// a Redis hiccup should not drop every open stream at once
+	return err != nil || n > 0
 }
 
 // ใช้แค่ auth (ไม่เช็ค permission)
@@ -108,6 +219,11 @@ func Authn() gin.HandlerFunc {
 
 // ใช้ auth + เช็ค permission
 func Authorize(required string) gin.HandlerFunc {
+	return AuthorizeAny(required)
+}
+
+// ใช้ auth + ต้องมีอย่างน้อยหนึ่ง permission ในรายการ (handler ตัดสินต่อเองจาก session.Perms)
+func AuthorizeAny(required ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		s, _, ok := authenticate(c)
 		if !ok {
@@ -116,20 +232,27 @@ func Authorize(required string) gin.HandlerFunc {
 		// check permission
 		has := false
 		for _, p := range s.Perms {
-			if p == required {
+			if slices.Contains(required, p) {
 				has = true
 				break
 			}
 		}
 		if !has {
-			logs.Logger.Printf("[Authorize] deny: need %s", required)
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			need := strings.Join(required, "|")
+			logs.Ctx(c).Warnf("[Authorize] deny: need %s", need)
+			Fail(c, errs.Forbidden("missing permission "+need).WithCode("missing_permission").WithParam("permission", need))
 			return
 		}
 		c.Next()
 	}
 }
 
+// HasPerm reports whether the caller's session holds the permission
+func HasPerm(c *gin.Context, perm string) bool {
+	s := GetSession(c)
+	return s != nil && slices.Contains(s.Perms, perm)
+}
+
 func GetSession(c *gin.Context) *Session {
 	if v, ok := c.Get("session"); ok {
 		if s, ok := v.(Session); ok {
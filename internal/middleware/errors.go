@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fail records err for ErrorHandler and stops the chain. Handlers and middleware
+// use it instead of writing error bodies themselves.
+func Fail(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// ErrorHandler renders the last error recorded with Fail/c.Error as RFC 7807
+// problem+json. Non-domain errors are logged with their real message and
+// reported to the client as a generic internal_error.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		var he *errs.HttpError
+		if !errors.As(err, &he) {
+			logs.Ctx(c).Errorf("unhandled error: %v", err)
+		}
+		p := errs.ToProblem(err)
+		if errors.As(err, &he) {
+			if msg, ok := i18n.Lookup(i18n.FromContext(c), "error."+p.Code, he.Params); ok {
+				p.Detail = msg
+			}
+		} else {
+			p.Detail = i18n.T(i18n.FromContext(c), "error."+errs.CodeInternal, nil)
+		}
+		p.Instance = c.Request.URL.Path
+		p.RequestID = c.GetString("requestID")
+		p.TraceID = c.GetString("traceID")
+
+		if p.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(p.RetryAfter))
+		}
+		c.Header("Content-Type", errs.ProblemContentType)
+		c.JSON(p.Status, p)
+	}
+}
+
+// NoRoute/NoMethod answer unknown routes with the same problem format
+func NoRoute(c *gin.Context) {
+	Fail(c, errs.NotFound("route not found").WithCode("route_not_found"))
+}
+
+func NoMethod(c *gin.Context) {
+	Fail(c, errs.New(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed"))
+}
@@ -1,23 +1,29 @@
 package middleware
 
 import (
+	"context"
+	"math"
 	"net"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/lifecycle"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
 )
 
-type limiterKey struct {
-	IP   string
-	Path string
-}
+// ===== in-process fallback (used when redis is unavailable) =====
 
 type store struct {
 	mu       sync.Mutex
-	limiters map[limiterKey]*entry
+	limiters map[string]*entry
 	ttl      time.Duration
 }
 
@@ -26,24 +32,28 @@ type entry struct {
 	last    time.Time
 }
 
-func newStore(ttl time.Duration) *store {
+func newStore(bg *lifecycle.Group, ttl time.Duration) *store {
 	s := &store{
-		limiters: make(map[limiterKey]*entry),
+		limiters: make(map[string]*entry),
 		ttl:      ttl,
 	}
 	// background cleanup
-	go func() {
+	bg.Go("ratelimit-gc", func(ctx context.Context) {
 		ticker := time.NewTicker(ttl)
 		defer ticker.Stop()
-		for range ticker.C {
-			s.gc()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.gc()
+			}
 		}
-	}()
+	})
 	return s
 }
 
-func (s *store) get(ip, path string, r rate.Limit, burst int) *rate.Limiter {
-	key := limiterKey{IP: ip, Path: path}
+func (s *store) get(key string, r rate.Limit, burst int) *rate.Limiter {
 	now := time.Now()
 
 	s.mu.Lock()
@@ -69,17 +79,12 @@ func (s *store) gc() {
 	s.mu.Unlock()
 }
 
-func clientIP(c *gin.Context) string {
-	ip := c.ClientIP()
-	if ip != "" {
+// ClientIP returns the caller's IP. Forwarding headers are only honoured when the
+// peer is a trusted proxy (see config.TrustedProxyConfig); never parse them directly.
+func ClientIP(c *gin.Context) string {
+	if ip := c.ClientIP(); ip != "" {
 		return ip
 	}
-
-	h := c.Request.Header.Get("X-Forwarded-For")
-	if h != "" {
-		parts := strings.Split(h, ",")
-		return strings.TrimSpace(parts[0])
-	}
 	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
 	if err == nil && host != "" {
 		return host
@@ -87,37 +92,133 @@ func clientIP(c *gin.Context) string {
 	return c.Request.RemoteAddr
 }
 
-// RateLimitPerMinute จำกัดจำนวนการเรียก API ต่อ IP ต่อเส้นทาง
-//   - limit: จำนวนครั้งสูงสุดต่อ 1 นาที (เช่น 60)
-//   - burst: จำนวนครั้งเกินพิเศษที่อนุญาต (เช่น 20)
-//   - ttl: เวลาที่เก็บสถานะ limiter ไว้ในหน่วยความจำ (เช่น 10 นาที)
-func RateLimitPerMinute(limit int, burst int, ttl time.Duration) gin.HandlerFunc {
-	if limit <= 0 {
-		limit = 60
+// ===== redis GCRA =====
+
+// gcraScript implements the generic cell rate algorithm; the key holds the
+// theoretical arrival time (TAT) in ms. Redis server time is used so replicas agree.
+// A request is allowed while the TAT is at most tolerance ahead of now, so with a
+// tolerance of (burst-1) emission intervals exactly burst requests pass at once.
+//
+// ARGV: emission interval ms, burst tolerance ms
+// returns: {allowed, remaining, retry_after_ms, reset_after_ms}
+var gcraScript = redis.NewScript(`
+local emission = tonumber(ARGV[1])
+local tolerance = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = t[1] * 1000 + math.floor(t[2] / 1000)
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+
+if tat - now > tolerance then
+  return {0, 0, tat - now - tolerance, tat - now}
+end
+
+local new_tat = tat + emission
+redis.call('SET', KEYS[1], new_tat, 'PX', new_tat - now)
+return {1, math.floor((tolerance - (new_tat - now)) / emission) + 1, 0, new_tat - now}
+`)
+
+type rateResult struct {
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration
+	resetAfter time.Duration
+}
+
+func allowRedis(ctx context.Context, rdb *redis.Client, key string, p config.RatePolicy) (*rateResult, error) {
+	emission := p.Window.Milliseconds() / int64(p.Limit)
+	if emission < 1 {
+		emission = 1
+	}
+	tolerance := emission * int64(p.Burst-1)
+	vals, err := gcraScript.Run(ctx, rdb, []string{key}, emission, tolerance).Int64Slice()
+	if err != nil {
+		return nil, err
 	}
-	if burst < 1 {
-		burst = 1
+	return &rateResult{
+		allowed:    vals[0] == 1,
+		remaining:  int(vals[1]),
+		retryAfter: time.Duration(vals[2]) * time.Millisecond,
+		resetAfter: time.Duration(vals[3]) * time.Millisecond,
+	}, nil
+}
+
+// allowLocal takes one token from lim and reports the same figures as the redis script:
+// the wait for the next token when rejected, and the time until the bucket is full again
+func allowLocal(lim *rate.Limiter) *rateResult {
+	now := time.Now()
+	res := &rateResult{allowed: true}
+	r := lim.ReserveN(now, 1)
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		res = &rateResult{retryAfter: d}
 	}
-	if ttl <= 0 {
-		ttl = 10 * time.Minute
+	tokens := lim.TokensAt(now)
+	if tokens > 0 {
+		res.remaining = int(tokens)
 	}
-	ratePerToken := rate.Every(time.Minute / time.Duration(limit))
-	st := newStore(ttl)
+	res.resetAfter = time.Duration((float64(lim.Burst()) - tokens) / float64(lim.Limit()) * float64(time.Second))
+	return res
+}
+
+// rateSubject keys by API key, then authenticated user, then client IP
+func rateSubject(c *gin.Context) string {
+	if s := peekSession(c); s != nil {
+		if id := c.GetString("apiKeyID"); id != "" {
+			return "key:" + id
+		}
+		return "user:" + s.UserID.String()
+	}
+	return "ip:" + ClientIP(c)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// RateLimit จำกัดจำนวนการเรียก API ต่อผู้ใช้ (หรือ IP ถ้ายังไม่ login) ต่อเส้นทาง
+//   - นับใน redis (GCRA) เพื่อให้ทุก replica ใช้ limit ร่วมกัน
+//   - policy เลือกตาม "METHOD /full/path" ใน cfg.Routes (ไม่มี = "default")
+//   - ถ้า redis ใช้ไม่ได้ จะ fallback ไปนับในหน่วยความจำของ pod
+func RateLimit(bg *lifecycle.Group, cfg config.RateLimitConfig) gin.HandlerFunc {
+	fallback := newStore(bg, 10*time.Minute)
 
 	return func(c *gin.Context) {
-		ip := clientIP(c)
 		path := c.FullPath()
 		if path == "" {
 			// for routes added without named path (shouldn’t happen normally)
 			path = c.Request.URL.Path
 		}
-		lim := st.get(ip, path, ratePerToken, burst)
-		if !lim.Allow() {
-			c.AbortWithStatusJSON(429, gin.H{
-				"error":       "rate_limited",
-				"message":     "Too many requests. Please slow down.",
-				"retry_after": 1, // seconds (hint only)
-			})
+		name, ok := cfg.Routes[c.Request.Method+" "+path]
+		if !ok {
+			name = "default"
+		}
+		policy, ok := cfg.Policies[name]
+		if !ok || name == config.RateLimitNone {
+			c.Next()
+			return
+		}
+
+		key := "rl:" + policy.Name + ":" + rateSubject(c) + ":" + c.Request.Method + " " + path
+		res, err := allowRedis(c, config.Rdb, key, policy)
+		if err != nil {
+			logs.Ctx(c).Warnf("[ratelimit] redis unavailable, using local limiter: %v", err)
+			res = allowLocal(fallback.get(key, rate.Every(policy.Window/time.Duration(policy.Limit)), policy.Burst))
+		}
+
+		// at most Burst requests are admitted at once, refilling at Limit per window;
+		// Limit/Remaining describe the burst so clients pacing on them are not rejected
+		c.Header("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(int(policy.Window.Seconds()))+";burst="+strconv.Itoa(policy.Burst))
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.resetAfter)))
+		if !res.allowed {
+			metrics.RateLimitRejections.WithLabelValues(policy.Name).Inc()
+			retry := ceilSeconds(res.retryAfter)
+			Fail(c, errs.RateLimited(retry))
 			return
 		}
 		c.Next()
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/pkg/lifecycle"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// 60/1m:3 is one request per second with three at once
+var testPolicy = config.RatePolicy{Name: "test", Limit: 60, Window: time.Minute, Burst: 3}
+
+func TestGCRA(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name  string
+		after time.Duration // since start
+		want  rateResult
+	}{
+		{"first of burst", 0, rateResult{allowed: true, remaining: 2, resetAfter: time.Second}},
+		{"second of burst", 0, rateResult{allowed: true, remaining: 1, resetAfter: 2 * time.Second}},
+		{"burst used up", 0, rateResult{allowed: true, remaining: 0, resetAfter: 3 * time.Second}},
+		{"exhausted", 0, rateResult{retryAfter: time.Second, resetAfter: 3 * time.Second}},
+		{"still exhausted", 500 * time.Millisecond, rateResult{retryAfter: 500 * time.Millisecond, resetAfter: 2500 * time.Millisecond}},
+		{"one emission later", time.Second, rateResult{allowed: true, remaining: 0, resetAfter: 3 * time.Second}},
+		{"fully refilled", 10 * time.Second, rateResult{allowed: true, remaining: 2, resetAfter: time.Second}},
+	}
+	for _, tt := range tests {
+		mr.SetTime(start.Add(tt.after))
+		got, err := allowRedis(context.Background(), rdb, "rl:test", testPolicy)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	prev := config.Rdb
+	config.Rdb = rdb
+	t.Cleanup(func() { config.Rdb = prev })
+	start := time.Unix(1700000000, 0)
+
+	bg := lifecycle.New()
+	t.Cleanup(func() { _ = bg.Stop(time.Second) })
+	r := gin.New()
+	r.Use(ErrorHandler(), RateLimit(bg, config.RateLimitConfig{
+		Policies: map[string]config.RatePolicy{"default": testPolicy, config.RateLimitNone: {}},
+		Routes:   map[string]string{"GET /health": config.RateLimitNone},
+	}))
+	r.GET("/x", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name                         string
+		after                        time.Duration
+		status                       int
+		remaining, reset, retryAfter string
+	}{
+		{"at burst", 0, http.StatusNoContent, "2", "1", ""},
+		{"second of burst", 0, http.StatusNoContent, "1", "2", ""},
+		{"burst used up", 0, http.StatusNoContent, "0", "3", ""},
+		{"after exhaustion", 0, http.StatusTooManyRequests, "0", "3", "1"},
+		{"retry rounds up", 200 * time.Millisecond, http.StatusTooManyRequests, "0", "3", "1"},
+		{"after refill", time.Minute, http.StatusNoContent, "2", "1", ""},
+	}
+	for _, tt := range tests {
+		mr.SetTime(start.Add(tt.after))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+		h := w.Header()
+		if w.Code != tt.status {
+			t.Fatalf("%s: status %d, want %d", tt.name, w.Code, tt.status)
+		}
+		if got := h.Get("RateLimit-Policy"); got != "60;w=60;burst=3" {
+			t.Errorf("%s: RateLimit-Policy = %q", tt.name, got)
+		}
+		if got := h.Get("RateLimit-Limit"); got != "3" {
+			t.Errorf("%s: RateLimit-Limit = %q, want the burst", tt.name, got)
+		}
+		if got := h.Get("RateLimit-Remaining"); got != tt.remaining {
+			t.Errorf("%s: RateLimit-Remaining = %q, want %q", tt.name, got, tt.remaining)
+		}
+		if got := h.Get("RateLimit-Reset"); got != tt.reset {
+			t.Errorf("%s: RateLimit-Reset = %q, want %q", tt.name, got, tt.reset)
+		}
+		if got := h.Get("Retry-After"); got != tt.retryAfter {
+			t.Errorf("%s: Retry-After = %q, want %q", tt.name, got, tt.retryAfter)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("exempt route: status %d, headers %v", w.Code, w.Header())
+	}
+}
@@ -0,0 +1,23 @@
+package api_key_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateApiKeyReq struct {
+	Name          string   `json:"name" binding:"required,max=120" example:"ats-sync"`
+	Permissions   []string `json:"permissions" binding:"required,min=1,max=50,dive,required,max=64" example:"card_view,card_add"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365" example:"90"`
+}
+
+// CreateApiKeyResp contains the raw key; it is only returned once at creation time
+type CreateApiKeyResp struct {
+	ID          uuid.UUID `json:"id" example:"b2a8f4e5-9e3b-4c2b-9e0f-7b2c1a6d1f33"`
+	Name        string    `json:"name" example:"ats-sync"`
+	Key         string    `json:"key" example:"itk_3f9a1c2e_Qm9wN2..."`
+	Prefix      string    `json:"prefix" example:"itk_3f9a1c2e"`
+	Permissions []string  `json:"permissions" example:"card_view,card_add"`
+	ExpiresAt   time.Time `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+}
@@ -0,0 +1,15 @@
+package attachment_models
+
+import "time"
+
+// UploadLimits is returned with the attachment list so clients can check a file before uploading
+type UploadLimits struct {
+	MaxBytes     int64    `json:"max_bytes" example:"26214400"`
+	AllowedTypes []string `json:"allowed_types" example:"application/pdf,image/png"`
+}
+
+// DownloadURLResp is a link that downloads the file without further authentication until ExpiresAt
+type DownloadURLResp struct {
+	URL       string    `json:"url" example:"https://tracker.example.com/interview-tracker/attachments/download?token=eyJhbGciOi..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-01-01T10:05:00Z"`
+}
@@ -1,6 +1,6 @@
 package auth_models
 
 type LoginReq struct {
-	Email    string `json:"email" binding:"required,email" example:"example@example.com"`
-	Password string `json:"password" binding:"required" example:"P@ssw0rd"`
+	Email    string `json:"email" binding:"required,email,max=254" example:"example@example.com"`
+	Password string `json:"password" binding:"required,max=72" trim:"false" example:"P@ssw0rd"`
 }
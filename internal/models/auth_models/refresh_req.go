@@ -1,5 +1,5 @@
 package auth_models
 
 type RefreshReq struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
+	RefreshToken string `json:"refresh_token" binding:"required,max=512"`
 }
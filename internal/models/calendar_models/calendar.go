@@ -0,0 +1,18 @@
+package calendar_models
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateFeedTokenReq struct {
+	Name string `json:"name" binding:"max=120" example:"Outlook ที่ทำงาน"`
+}
+
+// CreateFeedTokenResp contains the secret feed URL; it is only returned once at creation time
+type CreateFeedTokenResp struct {
+	ID     uuid.UUID `json:"id" example:"b2a8f4e5-9e3b-4c2b-9e0f-7b2c1a6d1f33"`
+	Name   string    `json:"name" example:"Outlook ที่ทำงาน"`
+	Prefix string    `json:"prefix" example:"itc_3f9a1c2e"`
+	Token  string    `json:"token" example:"itc_3f9a1c2e_Qm9wN2..."`
+	URL    string    `json:"url" example:"https://tracker.example.com/interview-tracker/calendar/feed.ics?token=itc_3f9a1c2e_Qm9wN2..."`
+}
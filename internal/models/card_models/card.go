@@ -2,35 +2,136 @@ package card_models
 
 import (
 	"time"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
 )
 
+// MaxMarkdownLength limits the Markdown fields (card description, comment content), in characters
+// of source; the max= binding tags on those fields use the same number
+const MaxMarkdownLength = 5000
+
+// TextLimits is returned with lists so clients can validate input and lay out excerpts
+type TextLimits struct {
+	Format            string `json:"format" example:"markdown"`
+	MaxMarkdownLength int    `json:"max_markdown_length" example:"5000"`
+	ExcerptLength     int    `json:"excerpt_length" example:"200"` // excerpts longer than this end in …
+}
+
+// Limits are the text limits of cards and comments
+var Limits = TextLimits{Format: "markdown", MaxMarkdownLength: MaxMarkdownLength, ExcerptLength: entities.ExcerptLength}
+
+type PageQuery struct {
+	Page     int `form:"page,default=1" binding:"min=1" example:"1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100" example:"10"`
+}
+
 type ListCardsQuery struct {
-	Page     int    `form:"page,default=1" example:"1"`
-	PageSize int    `form:"page_size,default=10" example:"10"`
-	Status   string `form:"status" example:"todo"` // todo|in_progress|done
+	PageQuery
+	Status string `form:"status" binding:"omitempty,oneof=todo in_progress done" example:"todo"`      // todo|in_progress|done
+	Sort   string `form:"sort,default=scheduled_at" binding:"oneof=scheduled_at rank" example:"rank"` // rank = board order
+}
+
+// BoardQuery sets how many cards each column returns; the rest of a column is
+// GET /cards?status=...&sort=rank with the same page_size
+type BoardQuery struct {
+	Limit int `form:"limit,default=20" binding:"min=1,max=100" example:"20"`
+}
+
+// BoardColumn is one status column of the board
+type BoardColumn struct {
+	Status *entities.CardStatus `json:"status"`
+	Total  int64                `json:"total"` // active cards in the column, including those not returned
+	Cards  []*entities.Card     `json:"cards"`
+}
+
+// MoveCardReq moves a card to status, right after after_id or right before before_id
+// (a card already in that column); with neither the card goes to the bottom of the column
+type MoveCardReq struct {
+	Status   string `json:"status" binding:"required,oneof=todo in_progress done" example:"in_progress"`
+	AfterID  string `json:"after_id" binding:"omitempty,uuid" example:"5b0f1f3e-8c1a-4a57-9d2e-3f4a5b6c7d8e"`
+	BeforeID string `json:"before_id" binding:"omitempty,uuid" example:""`
 }
 
 type CreateCardReq struct {
-	Title       string    `json:"title" binding:"required" example:"นัดสัมภาษณ์งาน 1"`
-	Description string    `json:"description" example:"สัมภาษณ์ตำแหน่ง Backend Developer"`
-	ScheduledAt time.Time `json:"scheduled_at" binding:"required" example:"2023-01-01T10:00:00Z"`
-	// AssigneeID  *uuid.UUID `json:"assignee_id" example:"888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"`
+	Title          string    `json:"title" binding:"required,max=200" example:"นัดสัมภาษณ์งาน 1"`
+	Description    string    `json:"description" binding:"max=5000" trim:"false" example:"สัมภาษณ์ตำแหน่ง Backend Developer"`
+	Position       string    `json:"position" binding:"max=200" example:"Backend Developer"`
+	ScheduledAt    time.Time `json:"scheduled_at" binding:"required,future" example:"2030-01-01T10:00:00+07:00"` // start
+	EndsAt         time.Time `json:"ends_at" example:"2030-01-01T11:00:00+07:00"`                                // default scheduled_at + 1h
+	TimeZone       string    `json:"time_zone" binding:"omitempty,timezone" example:"Asia/Bangkok"`              // default Asia/Bangkok
+	Location       string    `json:"location" binding:"max=500" example:"ห้องประชุม 3A"`
+	MeetingURL     string    `json:"meeting_url" binding:"omitempty,url,max=2000" example:"https://meet.example.com/abc-defg-hij"`
+	InterviewerIDs []string  `json:"interviewer_ids" binding:"max=20,dive,uuid" example:"888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"`
 }
 
+// UpdateCardReq changes only the fields that are sent. Sending scheduled_at without ends_at
+// moves the whole interview and keeps its length.
 type UpdateCardReq struct {
-	Title       *string    `json:"title" example:"นัดสัมภาษณ์งาน 2"`
-	Description *string    `json:"description" example:"สัมภาษณ์ตำแหน่ง Fullstack Developer"`
-	ScheduledAt *time.Time `json:"scheduled_at" example:"2023-01-02T15:00:00Z"`
+	Title          *string    `json:"title" binding:"omitempty,min=1,max=200" example:"นัดสัมภาษณ์งาน 2"`
+	Description    *string    `json:"description" binding:"omitempty,max=5000" trim:"false" example:"สัมภาษณ์ตำแหน่ง Fullstack Developer"`
+	Position       *string    `json:"position" binding:"omitempty,max=200" example:"Fullstack Developer"`
+	ScheduledAt    *time.Time `json:"scheduled_at" binding:"omitempty,future" example:"2030-01-02T15:00:00+07:00"` // without ends_at the end moves by the same amount
+	EndsAt         *time.Time `json:"ends_at" example:"2030-01-02T16:00:00+07:00"`
+	TimeZone       *string    `json:"time_zone" binding:"omitempty,timezone" example:"Asia/Bangkok"`
+	Location       *string    `json:"location" binding:"omitempty,max=500" example:"Online"`
+	MeetingURL     *string    `json:"meeting_url" binding:"omitempty,url,max=2000" example:"https://meet.example.com/abc-defg-hij"`
+	InterviewerIDs *[]string  `json:"interviewer_ids" binding:"omitempty,max=20,dive,uuid" example:"888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"` // replaces the list
+}
+
+// CalendarQuery is a time range (RFC 3339) filtered by interviewer or position.
+// With neither user_id nor position the caller's own interviews are returned.
+type CalendarQuery struct {
+	From     time.Time `form:"from" binding:"required" example:"2030-01-01T00:00:00+07:00"`
+	To       time.Time `form:"to" binding:"required" example:"2030-01-08T00:00:00+07:00"`
+	UserID   string    `form:"user_id" binding:"omitempty,uuid" example:"888f2c6b-cc1a-4e94-bd6e-d8ba0ac36fc3"`
+	Position string    `form:"position" binding:"max=200" example:"Backend Developer"`
+	TimeZone string    `form:"tz" binding:"omitempty,timezone" example:"Asia/Bangkok"` // render times in this zone instead of each card's
+}
+
+// CalendarEvent is one interview on the calendar, also used to list schedule conflicts
+type CalendarEvent struct {
+	CardID         uuid.UUID   `json:"card_id"`
+	Title          string      `json:"title"`
+	Position       string      `json:"position"`
+	StatusCode     string      `json:"status_code"`
+	StartsAt       time.Time   `json:"starts_at"`
+	EndsAt         time.Time   `json:"ends_at"`
+	TimeZone       string      `json:"time_zone"`
+	Location       string      `json:"location,omitempty"`
+	MeetingURL     string      `json:"meeting_url,omitempty"`
+	InterviewerIDs []uuid.UUID `json:"interviewer_ids"`
 }
 
 type UpdateCardStatusReq struct {
 	Status string `json:"status" binding:"required,oneof=todo in_progress done" example:"in_progress"`
 }
 
+// AddCommentReq may mention users with @name (the part of their email before '@') or @email
 type AddCommentReq struct {
-	Content string `json:"content" binding:"required" example:"ควรปรับปรุง portfolio ให้ละเอียดขึ้น"`
+	Content  string `json:"content" binding:"required,notEmpty,max=5000" trim:"false" example:"@somchai ควรปรับปรุง **portfolio** ให้ละเอียดขึ้น"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid" example:""` // reply to this comment; replies to a reply join its thread
+}
+
+// ListCommentsQuery: flat lists every comment newest first; threaded pages top-level comments
+// newest first, each with all of its replies oldest first. IncludeDeleted is honoured for
+// moderators (comment_delete) only.
+type ListCommentsQuery struct {
+	PageQuery
+	View           string `form:"view,default=flat" binding:"oneof=flat threaded" example:"threaded"`
+	IncludeDeleted bool   `form:"include_deleted" example:"false"`
+}
+
+// DeleteCommentQuery: a moderator deleting someone else's comment must give the reason
+type DeleteCommentQuery struct {
+	Reason string `form:"reason" binding:"max=500" example:"spam"`
+}
+
+type ReactionReq struct {
+	Emoji string `json:"emoji" binding:"required,max=32,emoji" example:"👍"`
 }
 
 type UpdateCommentReq struct {
-	Content string `json:"content" binding:"required" example:"ใช้ได้"`
+	Content string `json:"content" binding:"required,notEmpty,max=5000" trim:"false" example:"ใช้ได้"`
 }
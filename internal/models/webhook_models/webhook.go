@@ -0,0 +1,33 @@
+package webhook_models
+
+import (
+	"interview-tracker/internal/entities"
+)
+
+// Events must be names from usecases.EventTypes, or "*" for every event
+type CreateWebhookReq struct {
+	URL         string   `json:"url" binding:"required,http_url,max=2000" example:"https://hooks.example.com/interview-tracker"`
+	Description string   `json:"description" binding:"max=200" example:"Slack bot"`
+	Events      []string `json:"events" binding:"required,min=1,max=20,dive,oneof=* card.created card.updated card.status_changed card.moved card.archived comment.created comment.updated comment.deleted comment.restored attachment.added attachment.deleted" example:"card.created,card.status_changed"`
+}
+
+// UpdateWebhookReq changes only the fields that are sent; is_active=true re-enables a webhook
+// that was disabled after repeated failures and resets its failure count
+type UpdateWebhookReq struct {
+	URL         *string   `json:"url" binding:"omitempty,http_url,max=2000" example:"https://hooks.example.com/interview-tracker"`
+	Description *string   `json:"description" binding:"omitempty,max=200" example:"Slack bot"`
+	Events      *[]string `json:"events" binding:"omitempty,min=1,max=20,dive,oneof=* card.created card.updated card.status_changed card.moved card.archived comment.created comment.updated comment.deleted comment.restored attachment.added attachment.deleted" example:"card.created"`
+	IsActive    *bool     `json:"is_active" example:"true"`
+}
+
+// CreateWebhookResp contains the signing secret; it is only returned once at creation time
+type CreateWebhookResp struct {
+	*entities.Webhook
+	Secret string `json:"secret" example:"whsec_Qm9wN2..."`
+}
+
+type ListDeliveriesQuery struct {
+	Page     int    `form:"page,default=1" binding:"min=1" example:"1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100" example:"10"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending succeeded failed" example:"failed"` // pending|succeeded|failed
+}
@@ -0,0 +1,49 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// set at build time, e.g.
+//
+//	go build -ldflags "-X interview-tracker/internal/pkg/buildinfo.Version=1.2.0 -X interview-tracker/internal/pkg/buildinfo.Commit=$(git rev-parse --short HEAD)"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+var startedAt = time.Now()
+
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildTime string    `json:"build_time,omitempty"`
+	GoVersion string    `json:"go_version"`
+	StartedAt time.Time `json:"started_at"`
+	Uptime    string    `json:"uptime"`
+}
+
+func Get() Info {
+	commit := Commit
+	if commit == "" {
+		// fall back to the vcs stamp go embeds when building from a git checkout
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			for _, s := range bi.Settings {
+				if s.Key == "vcs.revision" {
+					commit = s.Value
+				}
+			}
+		}
+	}
+	return Info{
+		Version:   Version,
+		Commit:    commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		StartedAt: startedAt,
+		Uptime:    time.Since(startedAt).Round(time.Second).String(),
+	}
+}
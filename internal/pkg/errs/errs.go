@@ -2,15 +2,104 @@ package errs
 
 import "net/http"
 
+// stable machine-readable codes; clients switch on these, never on Message
+const (
+	CodeBadRequest   = "bad_request"
+	CodeValidation   = "validation_failed"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeRateLimited  = "rate_limited"
+	CodeInternal     = "internal_error"
+	CodeUnavailable  = "service_unavailable"
+)
+
+// HttpError is a domain error that knows how it should be shown to a client.
+// Usecases return these; middleware.ErrorHandler renders them as problem+json,
+// translating "error.<Code>" from the i18n catalog (Message is the English fallback).
+// Any other error is treated as internal and its text is never sent to the client.
 type HttpError struct {
-	Code    int
-	Message string
+	Status     int
+	Code       string
+	Message    string
+	Fields     map[string]string // per-field validation messages
+	RetryAfter int               // seconds, for rate_limited
+	Params     map[string]string // placeholders for the translated message ({permission}, ...)
+	Conflicts  any               // the conflicting resources, for 409 responses that list them
 }
 
 func (e *HttpError) Error() string {
 	return e.Message
 }
 
+// WithCode returns a copy with a more specific code, e.g. NotFound("card not found").WithCode("card_not_found")
+func (e *HttpError) WithCode(code string) *HttpError {
+	cp := *e
+	cp.Code = code
+	return &cp
+}
+
+// WithParam returns a copy with a placeholder value for the message catalog
+func (e *HttpError) WithParam(key, value string) *HttpError {
+	cp := *e
+	cp.Params = map[string]string{key: value}
+	for k, v := range e.Params {
+		if k != key {
+			cp.Params[k] = v
+		}
+	}
+	return &cp
+}
+
+// WithConflicts returns a copy that lists the resources the request collided with
+func (e *HttpError) WithConflicts(v any) *HttpError {
+	cp := *e
+	cp.Conflicts = v
+	return &cp
+}
+
+func New(status int, code, msg string) *HttpError {
+	return &HttpError{Status: status, Code: code, Message: msg}
+}
+
+func BadRequest(msg string) *HttpError {
+	return New(http.StatusBadRequest, CodeBadRequest, msg)
+}
+
+// Validation carries the field -> message map produced by request binding
+func Validation(msg string, fields map[string]string) *HttpError {
+	e := New(http.StatusUnprocessableEntity, CodeValidation, msg)
+	e.Fields = fields
+	return e
+}
+
 func Unauthorized(msg string) *HttpError {
-	return &HttpError{Code: http.StatusUnauthorized, Message: msg}
+	return New(http.StatusUnauthorized, CodeUnauthorized, msg)
+}
+
+func Forbidden(msg string) *HttpError {
+	return New(http.StatusForbidden, CodeForbidden, msg)
+}
+
+func NotFound(msg string) *HttpError {
+	return New(http.StatusNotFound, CodeNotFound, msg)
+}
+
+func Conflict(msg string) *HttpError {
+	return New(http.StatusConflict, CodeConflict, msg)
+}
+
+func RateLimited(retryAfter int) *HttpError {
+	e := New(http.StatusTooManyRequests, CodeRateLimited, "Too many requests. Please slow down.")
+	e.RetryAfter = retryAfter
+	return e
+}
+
+func Internal() *HttpError {
+	return New(http.StatusInternalServerError, CodeInternal, "internal server error")
+}
+
+func Unavailable(msg string) *HttpError {
+	return New(http.StatusServiceUnavailable, CodeUnavailable, msg)
 }
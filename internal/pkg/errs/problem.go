@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem details body (application/problem+json)
+// with a few extension members: code, trace_id, request_id, errors and conflicts.
+type Problem struct {
+	Type       string            `json:"type"`
+	Title      string            `json:"title"`
+	Status     int               `json:"status"`
+	Detail     string            `json:"detail,omitempty"`
+	Instance   string            `json:"instance,omitempty"`
+	Code       string            `json:"code"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	RetryAfter int               `json:"retry_after,omitempty"`
+	Conflicts  any               `json:"conflicts,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+}
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes the code to form the problem "type" URI
+const ProblemTypeBase = "urn:interview-tracker:problem:"
+
+// ToProblem converts any error to a Problem. Errors that are not *HttpError
+// become a generic internal_error so raw DB/driver messages never leak.
+func ToProblem(err error) *Problem {
+	var he *HttpError
+	if !errors.As(err, &he) {
+		he = Internal()
+	}
+	return &Problem{
+		Type:       ProblemTypeBase + he.Code,
+		Title:      http.StatusText(he.Status),
+		Status:     he.Status,
+		Detail:     he.Message,
+		Code:       he.Code,
+		Errors:     he.Fields,
+		RetryAfter: he.RetryAfter,
+		Conflicts:  he.Conflicts,
+	}
+}
@@ -0,0 +1,86 @@
+// Package events publishes domain events (card and comment changes) to external sinks.
+// Events reach the sinks through the transactional outbox, so every sink sees each event
+// at least once, in order per card. Sinks must tolerate repeats: Event.ID is the idempotency key.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Event is the envelope every sink receives
+type Event struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	Sequence  int64           `json:"sequence"` // increases with every event; compare within one card_id
+	CardID    uuid.UUID       `json:"card_id"`
+	ActorID   uuid.UUID       `json:"actor_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sink receives events from the outbox relay. An error means the event was not accepted
+// and will be offered again later.
+type Sink interface {
+	Publish(ctx context.Context, ev Event) error
+}
+
+// NamedSink lets the relay remember which sinks already accepted an event
+type NamedSink struct {
+	Name string
+	Sink Sink
+}
+
+type LogSink struct{}
+
+func (LogSink) Publish(ctx context.Context, ev Event) error {
+	logs.Ctx(ctx).WithField("event", ev).Infof("events| %s card=%s", ev.Type, ev.CardID)
+	return nil
+}
+
+// RedisStream appends events to a Redis Stream (XADD) trimmed to about maxLen entries.
+// An event already added in the last dedupeTTL is skipped, so relay retries rarely repeat it.
+type RedisStream struct {
+	rdb    *redis.Client
+	stream string
+	maxLen int64
+}
+
+const dedupeTTL = 24 * time.Hour
+
+func NewRedisStream(rdb *redis.Client, stream string, maxLen int64) *RedisStream {
+	return &RedisStream{rdb: rdb, stream: stream, maxLen: maxLen}
+}
+
+func (s *RedisStream) Publish(ctx context.Context, ev Event) error {
+	key := s.stream + ":seen:" + ev.ID.String()
+	if n, err := s.rdb.Exists(ctx, key).Result(); err != nil {
+		return err
+	} else if n > 0 {
+		return nil
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if err := s.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"event_id": ev.ID.String(),
+			"type":     ev.Type,
+			"card_id":  ev.CardID.String(),
+			"event":    data,
+		},
+	}).Err(); err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, key, 1, dedupeTTL).Err()
+}
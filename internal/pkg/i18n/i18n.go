@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// message catalogs, one flat JSON object per language: {"error.card_not_found": "..."}
+// Placeholders are written {name} and filled from params.
+//
+//go:embed locales/*.json
+var localeFS embed.FS
+
+const (
+	Thai    = "th"
+	English = "en"
+)
+
+var (
+	catalogs    = map[string]map[string]string{}
+	defaultLang = English
+	matcher     = language.NewMatcher([]language.Tag{language.English, language.Thai})
+)
+
+func init() {
+	for _, lang := range []string{English, Thai} {
+		b, err := localeFS.ReadFile("locales/" + lang + ".json")
+		if err != nil {
+			panic(err)
+		}
+		m := map[string]string{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			panic("i18n: locales/" + lang + ".json: " + err.Error())
+		}
+		catalogs[lang] = m
+	}
+}
+
+// SetDefault sets the language used when Accept-Language is missing or unsupported
+func SetDefault(lang string) {
+	if _, ok := catalogs[lang]; ok {
+		defaultLang = lang
+	}
+}
+
+// Default is the language set by SetDefault (DEFAULT_LANGUAGE)
+func Default() string { return defaultLang }
+
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Match picks the best supported language for an Accept-Language header
+func Match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return defaultLang
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return defaultLang
+	}
+	_, idx, conf := matcher.Match(tags...)
+	if conf == language.No {
+		return defaultLang
+	}
+	return []string{English, Thai}[idx]
+}
+
+type langKey struct{}
+
+func WithLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext returns the request language (a gin.Context works too)
+func FromContext(ctx context.Context) string {
+	if ctx != nil {
+		if lang, ok := ctx.Value(langKey{}).(string); ok {
+			return lang
+		}
+	}
+	return defaultLang
+}
+
+// Lookup returns the message for key in lang, falling back to the default language
+func Lookup(lang, key string, params map[string]string) (string, bool) {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[defaultLang][key]
+	}
+	if !ok {
+		return "", false
+	}
+	for k, v := range params {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return msg, true
+}
+
+// T is Lookup that returns the key itself when no catalog has it
+func T(lang, key string, params map[string]string) string {
+	if msg, ok := Lookup(lang, key, params); ok {
+		return msg
+	}
+	return key
+}
+
+// GinMiddleware resolves the language from Accept-Language once per request
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := Match(c.GetHeader("Accept-Language"))
+		c.Set("lang", lang)
+		c.Request = c.Request.WithContext(WithLang(c.Request.Context(), lang))
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
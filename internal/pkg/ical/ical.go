@@ -0,0 +1,111 @@
+// Package ical writes RFC 5545 iCalendar objects for calendar clients
+// (Outlook, Google Calendar, Apple Calendar).
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	prodID = "-//interview-tracker//Interview Tracker//EN"
+	// RFC 5545 3.3.5 form #2: UTC time, so no VTIMEZONE component is needed
+	utcLayout = "20060102T150405Z"
+	// RFC 5545 3.1: lines longer than 75 octets are folded
+	maxLine = 75
+)
+
+// Event is one VEVENT. Times are written in UTC; clients show them in the user's zone.
+type Event struct {
+	UID          string
+	Sequence     int
+	Start, End   time.Time
+	Stamp        time.Time // DTSTAMP, when the object was generated
+	LastModified time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Cancelled    bool
+}
+
+// Marshal returns a VCALENDAR named name containing events
+func Marshal(name string, events []Event) []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if name != "" {
+		w.line("X-WR-CALNAME", escape(name))
+	}
+	for _, e := range events {
+		w.event(e)
+	}
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type writer struct{ buf bytes.Buffer }
+
+func (w *writer) event(e Event) {
+	status := "CONFIRMED"
+	if e.Cancelled {
+		status = "CANCELLED"
+	}
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", escape(e.UID))
+	w.line("DTSTAMP", utc(e.Stamp))
+	w.line("DTSTART", utc(e.Start))
+	w.line("DTEND", utc(e.End))
+	w.line("SEQUENCE", strconv.Itoa(e.Sequence))
+	w.line("STATUS", status)
+	w.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION", escape(e.Location))
+	}
+	if e.URL != "" {
+		w.line("URL", e.URL)
+	}
+	if !e.LastModified.IsZero() {
+		w.line("LAST-MODIFIED", utc(e.LastModified))
+	}
+	w.line("TRANSP", "OPAQUE")
+	w.line("END", "VEVENT")
+}
+
+// line writes "NAME:value" folded at 75 octets (never inside a UTF-8 sequence) and ended with CRLF
+func (w *writer) line(name, value string) {
+	s := name + ":" + value
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLine - 1 // the leading space of a continuation line counts
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape encodes a TEXT value (RFC 5545 3.3.11)
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
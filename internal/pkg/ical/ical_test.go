@@ -0,0 +1,144 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// unfold reverses line folding (RFC 5545 3.1)
+func unfold(s string) string {
+	return strings.ReplaceAll(s, "\r\n ", "")
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"short", "hello"},
+		{"exactly 75 octets", strings.Repeat("a", maxLine-len("SUMMARY:"))},
+		{"ascii", strings.Repeat("abcdefghij", 30)},
+		{"thai (3-octet runes)", strings.Repeat("สัมภาษณ์งาน ", 20)},
+		{"emoji (4-octet runes)", strings.Repeat("👍", 60)},
+		{"mixed offsets", "x" + strings.Repeat("é👍ก", 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &writer{}
+			w.line("SUMMARY", tt.value)
+			out := w.buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line not ended with CRLF: %q", out)
+			}
+			for i, l := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+				if len(l) > maxLine {
+					t.Errorf("line %d is %d octets: %q", i, len(l), l)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, l)
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+			}
+			if got := unfold(out); got != "SUMMARY:"+tt.value+"\r\n" {
+				t.Errorf("unfolded = %q", got)
+			}
+		})
+	}
+}
+
+func TestLineFoldingFillsLines(t *testing.T) {
+	w := &writer{}
+	w.line("X", strings.Repeat("a", 200))
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\r\n"), "\r\n")
+	// 202 octets: 75 on the first line, then a space and 74 octets per line
+	want := []int{75, 75, 54}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(want))
+	}
+	for i, l := range lines {
+		if len(l) != want[i] {
+			t.Errorf("line %d is %d octets, want %d", i, len(l), want[i])
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"a,b", `a\,b`},
+		{"a;b", `a\;b`},
+		{`a\b`, `a\\b`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2", `line1\nline2`},
+		{"line1\rline2", `line1\nline2`},
+		{`\n`, `\\n`}, // a literal backslash-n is not a newline
+		{"Room 3A; floor 2, Bangkok", `Room 3A\; floor 2\, Bangkok`},
+		{"ห้อง: 3A", "ห้อง: 3A"}, // ':' needs no escaping in TEXT
+	}
+	for _, tt := range tests {
+		if got := escape(tt.in); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+	events := []Event{
+		{
+			UID:         "1@interview-tracker",
+			Sequence:    3,
+			Start:       start,
+			End:         start.Add(time.Hour),
+			Stamp:       time.Date(2029, 12, 1, 0, 0, 0, 0, time.UTC),
+			Summary:     "Interview, round 2",
+			Description: "Position: Backend\n\nbring laptop; charger",
+			Location:    "Room 3A",
+		},
+		{
+			UID:       "2@interview-tracker",
+			Start:     start,
+			End:       start.Add(time.Hour),
+			Stamp:     start,
+			Summary:   "Archived",
+			Cancelled: true,
+		},
+	}
+	out := unfold(string(Marshal("Team, Bangkok", events)))
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Team\\, Bangkok\r\n",
+		"DTSTART:20300101T030000Z\r\n",
+		"DTEND:20300101T040000Z\r\n",
+		"DTSTAMP:20291201T000000Z\r\n",
+		"SEQUENCE:3\r\n",
+		"SUMMARY:Interview\\, round 2\r\n",
+		"DESCRIPTION:Position: Backend\\n\\nbring laptop\\; charger\r\n",
+		"LOCATION:Room 3A\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q", want)
+		}
+	}
+
+	vevents := strings.Split(out, "BEGIN:VEVENT")[1:]
+	if len(vevents) != 2 {
+		t.Fatalf("got %d events, want 2", len(vevents))
+	}
+	if !strings.Contains(vevents[0], "STATUS:CONFIRMED\r\n") {
+		t.Errorf("active event is not CONFIRMED: %q", vevents[0])
+	}
+	if !strings.Contains(vevents[1], "STATUS:CANCELLED\r\n") || !strings.Contains(vevents[1], "SEQUENCE:0\r\n") {
+		t.Errorf("cancelled event: %q", vevents[1])
+	}
+	if strings.Contains(vevents[1], "DESCRIPTION") || strings.Contains(vevents[1], "LOCATION") || strings.Contains(vevents[1], "LAST-MODIFIED") {
+		t.Errorf("empty fields written: %q", vevents[1])
+	}
+}
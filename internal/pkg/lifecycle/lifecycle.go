@@ -0,0 +1,64 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/pkg/logs"
+)
+
+// Group owns the service's background goroutines (cleanup loops, workers, ...)
+// so shutdown can cancel them and wait until they have returned.
+type Group struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+func New() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{ctx: ctx, cancel: cancel, draining: make(chan struct{})}
+}
+
+// Draining is closed as soon as shutdown starts, before in-flight requests are drained.
+// Long-lived responses such as event streams end when it closes so they do not hold shutdown up.
+func (g *Group) Draining() <-chan struct{} { return g.draining }
+
+// Drain closes Draining; main registers it with http.Server.RegisterOnShutdown
+func (g *Group) Drain() {
+	g.drainOnce.Do(func() { close(g.draining) })
+}
+
+// Context is cancelled when Stop is called
+func (g *Group) Context() context.Context { return g.ctx }
+
+// Go runs fn in a goroutine; fn must return once ctx is done
+func (g *Group) Go(name string, fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g.ctx)
+		logs.Logger.Printf("lifecycle| %s stopped", name)
+	}()
+}
+
+// Stop cancels every goroutine and waits for them up to timeout
+func (g *Group) Stop(timeout time.Duration) error {
+	g.Drain()
+	g.cancel()
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return errors.New("timed out waiting for background goroutines")
+	}
+}
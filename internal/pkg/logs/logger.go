@@ -1,34 +1,71 @@
 package logs
 
 import (
-	"encoding/json"
-	"log"
+	"context"
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var Logger *log.Logger
-var childLogger = logrus.WithFields(logrus.Fields{
-	"service": "interview-tracker-service",
-})
+// Logger is the process wide structured logger. Use Ctx(ctx) inside a request so
+// every line carries request_id and trace_id; Logger itself is for startup/background logs.
+var Logger = logrus.NewEntry(logrus.StandardLogger())
 
-func LoggerConfig() {
+type requestIDKey struct{}
 
-	logger := log.New(
-		os.Stderr,
-		"|"+os.Getenv("GLOBAL_ENDPOINT")+"| ",
-		log.Flags(),
-	)
-	Logger = logger
+// WithRequestID stores the request id in ctx (read back by RequestID and the log hook)
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
 }
 
-func LogJson(info interface{}) {
-	jsonBytes, _ := json.Marshal(info)
-	Logger.Println("json| ", string(jsonBytes))
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
 }
 
-func LoggerInfo(info string) {
-	logrus.SetFormatter(&logrus.JSONFormatter{})
-	childLogger.Info(info)
+// LoggerConfig sets up the JSON (or text, for local dev) logger at the given level
+// ("debug", "info", "warn", "error").
+func LoggerConfig(globalEndpoint, level, format string) error {
+	l := logrus.New()
+	l.SetOutput(os.Stderr)
+	lv, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.SetLevel(lv)
+	if format == "text" {
+		l.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	} else {
+		l.SetFormatter(&logrus.JSONFormatter{})
+	}
+	l.AddHook(contextHook{})
+	Logger = l.WithField("service", globalEndpoint)
+	return nil
+}
+
+// Ctx returns a logger bound to ctx (a gin.Context works too)
+func Ctx(ctx context.Context) *logrus.Entry {
+	return Logger.WithContext(ctx)
+}
+
+// contextHook copies request_id and trace_id from the entry's context onto the line
+type contextHook struct{}
+
+func (contextHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (contextHook) Fire(e *logrus.Entry) error {
+	if e.Context == nil {
+		return nil
+	}
+	if id := RequestID(e.Context); id != "" {
+		e.Data["request_id"] = id
+	}
+	if sc := trace.SpanContextFromContext(e.Context); sc.HasTraceID() {
+		e.Data["trace_id"] = sc.TraceID().String()
+	}
+	return nil
 }
@@ -0,0 +1,85 @@
+package logs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Redactor masks sensitive headers and JSON fields before they reach the logs.
+// Names are matched case-insensitively; JSON fields are matched at any depth.
+type Redactor struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+func NewRedactor(headers, fields []string) *Redactor {
+	r := &Redactor{headers: map[string]bool{}, fields: map[string]bool{}}
+	for _, h := range headers {
+		r.headers[strings.ToLower(h)] = true
+	}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+func (r *Redactor) Headers(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if r.headers[strings.ToLower(k)] {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// Query masks query parameters whose name is a redacted field. prefix qualifies
+// generic names on routes where they are sensitive: with prefix "oauth_", the
+// parameter "code" is masked when "oauth_code" is a redacted field.
+func (r *Redactor) Query(q url.Values, prefix string) map[string]string {
+	out := make(map[string]string, len(q))
+	for k, v := range q {
+		name := strings.ToLower(k)
+		if r.fields[name] || (prefix != "" && r.fields[prefix+name]) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ",")
+	}
+	return out
+}
+
+// JSON returns body with configured fields masked. Bodies that are not valid JSON
+// are not logged at all, since we cannot tell what they contain.
+func (r *Redactor) JSON(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "[unparsable body omitted]"
+	}
+	b, _ := json.Marshal(r.walk(v))
+	return string(b)
+}
+
+func (r *Redactor) walk(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, e := range x {
+			if r.fields[strings.ToLower(k)] {
+				x[k] = redacted
+				continue
+			}
+			x[k] = r.walk(e)
+		}
+	case []any:
+		for i, e := range x {
+			x[i] = r.walk(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+package logs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testRedactor() *Redactor {
+	return NewRedactor(
+		[]string{"Authorization", "cookie", "X-API-Key"},
+		[]string{"password", "refresh_token", "Token", "oauth_code", "oauth_state"},
+	)
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer abc")
+	h.Set("X-Api-Key", "itk_secret")
+	h.Add("Cookie", "a=1")
+	h.Add("Cookie", "b=2")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+
+	want := map[string]string{
+		"Authorization": redacted,
+		"X-Api-Key":     redacted,
+		"Cookie":        redacted,
+		"Accept":        "text/html, application/json",
+	}
+	if got := testRedactor().Headers(h); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Headers = %v, want %v", got, want)
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		prefix string
+		want   map[string]string
+	}{
+		{"field names", "password=x&Token=y&page=2", "", map[string]string{"password": redacted, "Token": redacted, "page": "2"}},
+		{"repeated values", "tag=a&tag=b", "", map[string]string{"tag": "a,b"}},
+		{"code kept without prefix", "code=abc&state=xyz", "", map[string]string{"code": "abc", "state": "xyz"}},
+		{"sso callback", "code=abc&state=xyz&session_state=s", "oauth_", map[string]string{"code": redacted, "state": redacted, "session_state": "s"}},
+		{"prefix does not hide plain fields", "password=x", "oauth_", map[string]string{"password": redacted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := testRedactor().Query(q, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Query(%q, %q) = %v, want %v", tt.query, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"top level", `{"email":"a@b.c","password":"hunter2"}`, `{"email":"a@b.c","password":"[REDACTED]"}`},
+		{"case-insensitive", `{"PassWord":"x","TOKEN":"y"}`, `{"PassWord":"[REDACTED]","TOKEN":"[REDACTED]"}`},
+		{"nested object", `{"user":{"name":"n","auth":{"refresh_token":"r"}}}`, `{"user":{"auth":{"refresh_token":"[REDACTED]"},"name":"n"}}`},
+		{"inside arrays", `{"items":[{"token":"a"},{"token":"b","id":1}]}`, `{"items":[{"token":"[REDACTED]"},{"id":1,"token":"[REDACTED]"}]}`},
+		{"whole subtree masked", `{"password":{"old":"a","new":"b"}}`, `{"password":"[REDACTED]"}`},
+		{"top-level array", `[{"password":"x"}]`, `[{"password":"[REDACTED]"}]`},
+		{"nothing to mask", `{"title":"t","n":1.5,"ok":true,"none":null}`, `{"n":1.5,"none":null,"ok":true,"title":"t"}`},
+		{"not json", `password=hunter2`, "[unparsable body omitted]"},
+		{"truncated json", `{"password":"hun`, "[unparsable body omitted]"},
+		{"empty", ``, "[unparsable body omitted]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testRedactor().JSON([]byte(tt.body))
+			if got != tt.want {
+				t.Fatalf("JSON(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+			if strings.Contains(got, "hunter2") {
+				t.Fatalf("JSON(%s) leaked the password", tt.body)
+			}
+			if got != "[unparsable body omitted]" && !json.Valid([]byte(got)) {
+				t.Fatalf("JSON(%s) = %s is not valid JSON", tt.body, got)
+			}
+		})
+	}
+}
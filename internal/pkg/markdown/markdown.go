@@ -0,0 +1,98 @@
+// Package markdown renders comment and description Markdown (CommonMark + GitHub tables,
+// strikethrough, task lists and autolinks) to HTML that is safe to insert into a page.
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// raw HTML in the source is dropped by goldmark; the allow-list below is the real guard
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(gmhtml.WithHardWraps()),
+	)
+	policy = newPolicy()
+	strip  = bluemonday.StrictPolicy()
+)
+
+// newPolicy allows the elements Markdown produces: text formatting, lists, tables, code blocks
+// (with a language class), images, and http(s)/mailto links opened in a new tab
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// GFM task list checkboxes
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// HTML renders src and sanitizes the result
+func HTML(src string) string {
+	if src == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		// goldmark only fails on writer errors; fall back to the escaped source
+		return "<p>" + html.EscapeString(src) + "</p>"
+	}
+	return policy.Sanitize(buf.String())
+}
+
+// Excerpt is the rendered text without markup, whitespace collapsed, cut to at most n characters
+func Excerpt(src string, n int) string {
+	text := html.UnescapeString(strip.Sanitize(HTML(src)))
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > n {
+		return strings.TrimSpace(string(r[:n])) + "…"
+	}
+	return text
+}
+
+// Prose returns the text a reader sees as prose: code spans, code blocks and raw HTML are
+// left out, so a handle like @param inside code is not mistaken for a mention. Blocks and
+// skipped nodes are separated by whitespace.
+func Prose(src string) string {
+	source := []byte(src)
+	doc := md.Parser().Parse(text.NewReader(source))
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				b.WriteByte('\n')
+			}
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			b.WriteByte(' ')
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.AutoLink:
+			b.Write(n.Label(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLStripsUnsafeMarkup(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		banned  []string // must not appear in the output (case-insensitive)
+		present []string // must appear in the output
+	}{
+		{"inline script tag", "hi <script>alert(1)</script>", []string{"<script"}, []string{"hi"}},
+		{"script block", "<script>\nalert(1)\n</script>", []string{"<script", "alert"}, nil},
+		{"javascript link", "[x](javascript:alert(1))", []string{"javascript:", "href"}, []string{"x"}},
+		{"mixed case javascript link", "[x](JaVaScRiPt:alert(1))", []string{"javascript:", "href"}, nil},
+		{"entity encoded javascript link", "[x](&#106;avascript:alert(1))", []string{"javascript:", "href"}, nil},
+		{"hex entity javascript link", "[x](&#x6A;avascript&#x3A;alert(1))", []string{"javascript:", "href"}, nil},
+		{"javascript in raw anchor", `<a href="javascript:alert(1)">x</a>`, []string{"javascript:", "href"}, nil},
+		{"data link", "[x](data:text/html;base64,PHNjcmlwdD4=)", []string{"data:", "href"}, nil},
+		{"onerror on raw img", `<img src=x onerror=alert(1)>`, []string{"onerror", "alert"}, nil},
+		{"onerror smuggled in image title", `![i](x.png "t\" onerror=\"alert(1)")`, []string{` onerror=`}, nil},
+		{"raw html block", "<div onclick=\"steal()\">\nraw block\n</div>", []string{"<div", "onclick"}, nil},
+		{"iframe", `<iframe src="https://evil.example"></iframe>`, []string{"<iframe"}, nil},
+		{"style", "<style>body{display:none}</style>", []string{"<style", "display:none"}, nil},
+		{"text input", `<input type="text" value="x" onfocus="y">`, []string{"<input", "onfocus"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HTML(tt.src)
+			lower := strings.ToLower(got)
+			for _, b := range tt.banned {
+				if strings.Contains(lower, strings.ToLower(b)) {
+					t.Errorf("HTML(%q) = %q contains %q", tt.src, got, b)
+				}
+			}
+			for _, p := range tt.present {
+				if !strings.Contains(got, p) {
+					t.Errorf("HTML(%q) = %q lacks %q", tt.src, got, p)
+				}
+			}
+		})
+	}
+}
+
+func TestHTMLKeepsMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"emphasis and code", "**bold** and `code`", "<p><strong>bold</strong> and <code>code</code></p>\n"},
+		{"hard wraps", "line1\nline2", "<p>line1<br>\nline2</p>\n"},
+		{"fenced code keeps language", "```go\nx := 1\n```", "<pre><code class=\"language-go\">x := 1\n</code></pre>\n"},
+		{"autolink opens in new tab", "see https://example.com", `<p>see <a href="https://example.com" rel="nofollow noreferrer noopener" target="_blank">https://example.com</a></p>` + "\n"},
+		{"task list", "- [x] done\n- [ ] todo", "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n<li><input disabled=\"\" type=\"checkbox\"> todo</li>\n</ul>\n"},
+		{"indented code block", "    @Override\n    void f()", "<pre><code>@Override\nvoid f()\n</code></pre>\n"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTML(tt.src); got != tt.want {
+				t.Errorf("HTML(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+// goldmark drops raw HTML before the policy sees it; these check the allow-list on its own
+func TestPolicyInputs(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`<input type="checkbox" checked disabled>`, `<input type="checkbox" checked="" disabled="">`},
+		{`<input type="text" value="x">`, ``},
+		{`<input type="checkbox" onclick="steal()" name="n" value="v">`, `<input type="checkbox">`},
+		{`<input type="password">`, ``},
+		{`<code class="language-go" onclick="x">a</code>`, `<code class="language-go">a</code>`},
+		{`<code class="evil">a</code>`, `<code>a</code>`},
+	}
+	for _, tt := range tests {
+		if got := policy.Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		n    int
+		want string
+	}{
+		{"markup removed", "# Title\n\n**bold** _it_ [link](https://example.com)", 100, "Title bold it link"},
+		{"entities decoded", "Tom &amp; Jerry <3", 100, "Tom & Jerry <3"},
+		{"whitespace collapsed", "a\n\n\n   b\tc", 100, "a b c"},
+		{"cut with ellipsis", "one two three four", 9, "one two t…"},
+		{"cut counts characters", "สวัสดีครับ", 5, "สวัสด…"},
+		{"tags dropped", "before <script>alert(1)</script> after", 100, "before alert(1) after"},
+		{"exactly n", "abcde", 5, "abcde"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Excerpt(tt.src, tt.n); got != tt.want {
+				t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.src, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProse(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		has     []string
+		missing []string
+	}{
+		{"plain", "hi @bob", []string{"@bob"}, nil},
+		{"code span", "use `@param` here, @alice", []string{"@alice"}, []string{"@param"}},
+		{"fenced code", "```java\n@Override\nvoid f()\n```\n@carol", []string{"@carol"}, []string{"@Override"}},
+		{"indented code", "text\n\n    @Deprecated\n\nafter @dave", []string{"@dave"}, []string{"@Deprecated"}},
+		{"raw html", "<b>@html</b> and @erin", []string{"@erin"}, []string{"<b>"}},
+		{"emphasis and links", "**@frank** [@grace](https://example.com)", []string{"@frank", "@grace"}, nil},
+		{"email mention stays whole", "ping @heidi@example.com.", []string{"@heidi@example.com"}, nil},
+		{"code does not join words", "foo`x`@ivan", []string{" @ivan"}, []string{"foo@ivan"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Prose(tt.src)
+			for _, h := range tt.has {
+				if !strings.Contains(got, h) {
+					t.Errorf("Prose(%q) = %q lacks %q", tt.src, got, h)
+				}
+			}
+			for _, m := range tt.missing {
+				if strings.Contains(got, m) {
+					t.Errorf("Prose(%q) = %q contains %q", tt.src, got, m)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormPlugin times every GORM statement into DBQueryDuration
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "metrics" }
+
+const startKey = "metrics:start"
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	before := func(tx *gorm.DB) { tx.InstanceSet(startKey, time.Now()) }
+	after := func(op string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(startKey)
+			if !ok {
+				return
+			}
+			table := tx.Statement.Table
+			if table == "" {
+				table = "raw"
+			}
+			DBQueryDuration.WithLabelValues(op, table).Observe(time.Since(v.(time.Time)).Seconds())
+		}
+	}
+
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
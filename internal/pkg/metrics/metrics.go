@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "interview_tracker"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	RateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ratelimit_rejections_total",
+		Help:      "Requests rejected by the rate limiter by policy.",
+	}, []string{"policy"})
+
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_logins_total",
+		Help:      "Login attempts by method (password, sso) and result (success, failure).",
+	}, []string{"method", "result"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "GORM statement latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	RedisCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Redis command latency by command name.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25},
+	}, []string{"command"})
+
+	CardsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cards_created_total",
+		Help:      "Cards created by initial status.",
+	}, []string{"status"})
+
+	CardStatusTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "card_status_transitions_total",
+		Help:      "Card status changes by from/to status.",
+	}, []string{"from", "to"})
+
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_job_runs_total",
+		Help:      "Background job slots by job and result (ok, error, skipped = another replica ran it, lock_error).",
+	}, []string{"job", "result"})
+
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scheduler_job_duration_seconds",
+		Help:      "Background job run time by job.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60},
+	}, []string{"job"})
+
+	Notifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Notifications by kind and result (sent, failed).",
+	}, []string{"kind", "result"})
+
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_attempts_total",
+		Help:      "Webhook delivery attempts by event type and result (succeeded, retry, failed).",
+	}, []string{"event", "result"})
+
+	OutboxEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_total",
+		Help:      "Outbox events offered to each sink by result (published, retry).",
+	}, []string{"sink", "result"})
+
+	AttachmentUploads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "attachment_uploads_total",
+		Help:      "Attachment uploads by result (stored, too_large, rejected = empty or type not allowed, failed).",
+	}, []string{"result"})
+
+	AttachmentBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "attachment_bytes_stored_total",
+		Help:      "Bytes of attachments stored.",
+	})
+)
+
+// GinMiddleware records request count and latency labelled by c.FullPath(),
+// so path params don't explode label cardinality.
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		HTTPDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the Prometheus exposition format. It is mounted on the admin
+// listener (METRICS_PORT), never on the public router.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHook times every go-redis command into RedisCommandDuration
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		RedisCommandDuration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		RedisCommandDuration.WithLabelValues("pipeline").Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+var _ redis.Hook = RedisHook{}
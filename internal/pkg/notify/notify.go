@@ -0,0 +1,82 @@
+// Package notify delivers user notifications (interview reminders, stale-card alerts).
+// Usecases depend on the Notifier interface; the driver is picked by configuration.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/google/uuid"
+)
+
+type Recipient struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Email  string    `json:"email"`
+}
+
+type Notification struct {
+	Kind       string      `json:"kind"` // reminder, stale_card
+	CardID     uuid.UUID   `json:"card_id"`
+	Recipients []Recipient `json:"recipients"`
+	Subject    string      `json:"subject"`
+	Body       string      `json:"body"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// Notifier delivers one notification to all of its recipients.
+// An error means nothing was delivered and the caller may retry later.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// New returns the notifier for driver: "log" writes to the service log,
+// "file" appends one JSON line per notification to path (handy for local testing)
+func New(driver, path string) (Notifier, error) {
+	switch driver {
+	case "log":
+		return LogNotifier{}, nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("notify: file driver needs a path")
+		}
+		return &FileNotifier{path: path}, nil
+	}
+	return nil, fmt.Errorf("notify: unknown driver %q", driver)
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, n Notification) error {
+	logs.Ctx(ctx).WithField("notification", n).Infof("notify| %s: %s", n.Kind, n.Subject)
+	return nil
+}
+
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (f *FileNotifier) Notify(_ context.Context, n Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(b, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,90 @@
+// Package rank implements fractional indexing: string keys that sort in byte order, where a new
+// key can always be made between any two others, so moving one card writes one row.
+//
+// Keys use the digits 0-9a-z and never end in '0'; the empty string stands for the start
+// (as lower bound) or the end (as upper bound) of the list.
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// MaxLen is the length past which callers should renumber the list with Spread:
+// keys grow by about one digit per five inserts at the same spot.
+const MaxLen = 64
+
+// ErrOrder is returned by Between when a is not below b, or a key is malformed
+var ErrOrder = errors.New("rank: keys out of order")
+
+// Between returns a key that sorts after a and before b
+func Between(a, b string) (string, error) {
+	if !valid(a) || !valid(b) || (b != "" && a >= b) {
+		return "", ErrOrder
+	}
+	return midpoint(a, b), nil
+}
+
+// After returns a key that sorts after a, i.e. at the end of the list when a is the last key
+func After(a string) (string, error) { return Between(a, "") }
+
+// Spread returns the i-th (1-based) of evenly spaced fixed-width keys. It must match the
+// renumbering done in SQL: lpad(to_hex(i), 8, '0') || 'i'.
+func Spread(i int) string { return fmt.Sprintf("%08xi", i) }
+
+// midpoint assumes a < b, or b == "" for no upper bound
+func midpoint(a, b string) string {
+	if b != "" {
+		// keep the common prefix, treating a as padded with '0'
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+	da := 0
+	if a != "" {
+		da = strings.IndexByte(digits, a[0])
+	}
+	db := len(digits)
+	if b != "" {
+		db = strings.IndexByte(digits, b[0])
+	}
+	if db-da > 1 {
+		return string(digits[(da+db)/2])
+	}
+	// the first digits are consecutive
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[da]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
+
+func valid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(s, digits[:1])
+}
@@ -0,0 +1,125 @@
+package rank
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"empty list", "", "", "i"},
+		{"before first", "", "i", "9"},
+		{"after last", "i", "", "r"},
+		{"wide gap", "1", "9", "5"},
+		{"adjacent digits", "a", "b", "ai"},
+		{"adjacent, upper longer", "a", "b5", "b"},
+		{"common prefix", "a1", "a9", "a5"},
+		{"prefix of upper", "a", "a1", "a0i"},
+		{"lowest digit", "", "1", "0i"},
+		{"below leading zeros", "", "001", "000i"},
+		{"highest digit", "z", "", "zi"},
+		{"after all z", "zz", "", "zzi"},
+		{"spread neighbours", Spread(1), Spread(2), "00000002"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Between(%q, %q) error: %v", tt.a, tt.b, err)
+			}
+			if got != tt.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+			if !valid(got) || got <= tt.a || (tt.b != "" && got >= tt.b) {
+				t.Errorf("Between(%q, %q) = %q is not a valid key strictly between", tt.a, tt.b, got)
+			}
+		})
+	}
+}
+
+func TestBetweenRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"equal", "a", "a"},
+		{"reversed", "b", "a"},
+		{"trailing zero", "a0", ""},
+		{"uppercase", "A", ""},
+		{"outside alphabet", "", "a-"},
+		{"zero upper bound", "", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Between(tt.a, tt.b); !errors.Is(err, ErrOrder) {
+				t.Errorf("Between(%q, %q) = %q, %v; want ErrOrder", tt.a, tt.b, got, err)
+			}
+		})
+	}
+}
+
+func TestRepeatedInserts(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi string
+		front  bool // the new key becomes the upper bound of the next insert
+	}{
+		{"at the front", "", "", true},
+		{"at the end", "", "", false},
+		{"after the same card", "a", "b", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := tt.lo, tt.hi
+			for i := 0; i < 200; i++ {
+				r, err := Between(lo, hi)
+				if err != nil {
+					t.Fatalf("insert %d: Between(%q, %q) error: %v", i, lo, hi, err)
+				}
+				if r <= lo || (hi != "" && r >= hi) {
+					t.Fatalf("insert %d: Between(%q, %q) = %q out of order", i, lo, hi, r)
+				}
+				if tt.front {
+					hi = r
+				} else {
+					lo = r
+				}
+			}
+			if n := max(len(lo), len(hi)); n > MaxLen {
+				t.Errorf("key grew to %d digits after 200 inserts, want <= %d", n, MaxLen)
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{1, "00000001i"},
+		{10, "0000000ai"},
+		{255, "000000ffi"},
+		{4096, "00001000i"},
+	}
+	for _, tt := range tests {
+		if got := Spread(tt.i); got != tt.want {
+			t.Errorf("Spread(%d) = %q, want %q", tt.i, got, tt.want)
+		}
+	}
+
+	// ranks are compared with COLLATE "C", i.e. byte order
+	for i := 1; i < 5000; i++ {
+		a, b := Spread(i), Spread(i+1)
+		if !valid(a) || a >= b {
+			t.Fatalf("Spread(%d) = %q, Spread(%d) = %q: not valid keys in byte order", i, a, i+1, b)
+		}
+		if _, err := Between(a, b); err != nil {
+			t.Fatalf("Between(Spread(%d), Spread(%d)) error: %v", i, i+1, err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package realtime pushes board events to Server-Sent Events subscribers on every replica.
+//
+// The outbox relay hands each event to Sink once; Sink appends it to a capped Redis Stream
+// and announces it on Redis pub/sub under the same name. Every replica runs a Hub that
+// receives the announcements and fans them out to its local subscribers. The stream keeps
+// recent events so a reconnecting client can resume after its Last-Event-ID.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/pkg/events"
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Message is one event as subscribers receive it. ID is the Redis Stream entry id,
+// which clients send back as Last-Event-ID.
+type Message struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"` // the events.Event
+}
+
+// Sink is the outbox relay sink that feeds the hubs
+type Sink struct {
+	rdb    *redis.Client
+	stream string
+	maxLen int64
+}
+
+// an event already added in this window is not added again when the relay offers it again
+const dedupeTTL = 24 * time.Hour
+
+// published replaces the stream id in the dedupe key once the event has been announced
+const published = "published"
+
+// addOnce appends the event to the stream unless its dedupe key exists, and returns the
+// key's value: the stream id of the entry (new or from an earlier attempt), or published.
+// Adding and recording the id in one script means a retry after a failed PUBLISH
+// announces the same entry instead of adding a second one.
+// KEYS: stream, dedupe key. ARGV: max len, type, event JSON, ttl ms
+var addOnce = redis.NewScript(`
+local v = redis.call('GET', KEYS[2])
+if v then
+  return v
+end
+local id = redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[1], '*', 'type', ARGV[2], 'event', ARGV[3])
+redis.call('SET', KEYS[2], id, 'PX', ARGV[4])
+return id
+`)
+
+func NewSink(rdb *redis.Client, stream string, maxLen int64) *Sink {
+	return &Sink{rdb: rdb, stream: stream, maxLen: maxLen}
+}
+
+func (s *Sink) Publish(ctx context.Context, ev events.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	seen := s.stream + ":seen:" + ev.ID.String()
+	id, err := addOnce.Run(ctx, s.rdb, []string{s.stream, seen}, s.maxLen, ev.Type, data, dedupeTTL.Milliseconds()).Text()
+	if err != nil {
+		return err
+	}
+	if id == published {
+		return nil
+	}
+	msg, err := json.Marshal(Message{ID: id, Type: ev.Type, Data: data})
+	if err != nil {
+		return err
+	}
+	if err := s.rdb.Publish(ctx, s.stream, msg).Err(); err != nil {
+		return err
+	}
+	return s.rdb.SetArgs(ctx, seen, published, redis.SetArgs{KeepTTL: true}).Err()
+}
+
+// Subscription receives messages until the hub closes C: on shutdown, or when the
+// subscriber falls too far behind (it should reconnect and resume).
+type Subscription struct {
+	C chan Message
+}
+
+// messages buffered per subscriber before it is dropped as too slow
+const subscriberBuffer = 256
+
+type Hub struct {
+	rdb    *redis.Client
+	stream string
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+}
+
+func NewHub(rdb *redis.Client, stream string) *Hub {
+	return &Hub{rdb: rdb, stream: stream, subs: map[*Subscription]struct{}{}}
+}
+
+// Run forwards pub/sub messages to the local subscribers until ctx is done
+func (h *Hub) Run(ctx context.Context) {
+	ps := h.rdb.Subscribe(ctx, h.stream)
+	defer ps.Close()
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			h.closeAll()
+			return
+		case m, ok := <-ch:
+			if !ok {
+				h.closeAll()
+				return
+			}
+			var msg Message
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				logs.Logger.Errorf("realtime| bad message on %s: %v", h.stream, err)
+				continue
+			}
+			h.broadcast(msg)
+		}
+	}
+}
+
+func (h *Hub) Subscribe() *Subscription {
+	s := &Subscription{C: make(chan Message, subscriberBuffer)}
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.C)
+	}
+}
+
+func (h *Hub) broadcast(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs {
+		select {
+		case s.C <- m:
+		default:
+			delete(h.subs, s)
+			close(s.C)
+		}
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs {
+		delete(h.subs, s)
+		close(s.C)
+	}
+}
+
+// Since returns up to limit events added after lastID. complete is false when some of them
+// are no longer available (trimmed from the stream, or more than limit): the client must reload.
+func (h *Hub) Since(ctx context.Context, lastID string, limit int) (msgs []Message, complete bool, err error) {
+	first, err := h.rdb.XRangeN(ctx, h.stream, "-", "+", 1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(first) > 0 && CompareIDs(lastID, first[0].ID) < 0 {
+		return nil, false, nil
+	}
+	entries, err := h.rdb.XRangeN(ctx, h.stream, "("+lastID, "+", int64(limit)+1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	complete = len(entries) <= limit
+	if !complete {
+		entries = entries[:limit]
+	}
+	for _, e := range entries {
+		typ, _ := e.Values["type"].(string)
+		data, _ := e.Values["event"].(string)
+		msgs = append(msgs, Message{ID: e.ID, Type: typ, Data: json.RawMessage(data)})
+	}
+	return msgs, complete, nil
+}
+
+var streamID = regexp.MustCompile(`^\d+-\d+$`)
+
+// ValidID reports whether id has the Redis Stream id form <ms>-<seq>
+func ValidID(id string) bool { return streamID.MatchString(id) }
+
+// CompareIDs orders two stream ids like strings.Compare; both must be valid
+func CompareIDs(a, b string) int {
+	am, as, _ := strings.Cut(a, "-")
+	bm, bs, _ := strings.Cut(b, "-")
+	for _, p := range [][2]string{{am, bm}, {as, bs}} {
+		x, _ := strconv.ParseUint(p[0], 10, 64)
+		y, _ := strconv.ParseUint(p[1], 10, 64)
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
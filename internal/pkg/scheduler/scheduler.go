@@ -0,0 +1,101 @@
+// Package scheduler runs periodic background jobs inside the service.
+// Every replica runs the same loop, but a Redis key per job and time slot
+// makes sure only one replica fires each run.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"interview-tracker/internal/pkg/lifecycle"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const lockPrefix = "scheduler:"
+
+// Job runs every Interval, aligned to wall-clock multiples of Interval
+// (a 5m job fires at :00, :05, ...) so replicas agree on the slot.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Timeout limits one run; Interval when zero. Only a job that guards against overlapping
+	// runs itself may set it longer than Interval.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type Runner struct {
+	rdb   *redis.Client
+	owner string
+	jobs  []Job
+}
+
+func New(rdb *redis.Client) *Runner {
+	host, _ := os.Hostname()
+	return &Runner{rdb: rdb, owner: fmt.Sprintf("%s/%d/%s", host, os.Getpid(), uuid.NewString()[:8])}
+}
+
+func (r *Runner) Add(j Job) {
+	r.jobs = append(r.jobs, j)
+}
+
+// Start runs every job loop in bg; they stop when bg is stopped
+func (r *Runner) Start(bg *lifecycle.Group) {
+	for _, j := range r.jobs {
+		j := j
+		bg.Go("job "+j.Name, func(ctx context.Context) { r.loop(ctx, j) })
+		logs.Logger.Printf("scheduler| %s every %s", j.Name, j.Interval)
+	}
+}
+
+func (r *Runner) loop(ctx context.Context, j Job) {
+	for {
+		next := time.Now().Truncate(j.Interval).Add(j.Interval)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			r.fire(ctx, j, next)
+		}
+	}
+}
+
+// fire claims the slot with SET NX; the key expires with the slot, so it is never released explicitly
+func (r *Runner) fire(ctx context.Context, j Job, slot time.Time) {
+	key := fmt.Sprintf("%s%s:%d", lockPrefix, j.Name, slot.Unix())
+	ok, err := r.rdb.SetNX(ctx, key, r.owner, j.Interval).Result()
+	if err != nil {
+		logs.Logger.Warnf("scheduler| %s: lock failed: %v", j.Name, err)
+		metrics.JobRuns.WithLabelValues(j.Name, "lock_error").Inc()
+		return
+	}
+	if !ok {
+		metrics.JobRuns.WithLabelValues(j.Name, "skipped").Inc()
+		return
+	}
+
+	// by default the run may not outlive its slot, or it would overlap the next one
+	timeout := j.Interval
+	if j.Timeout > 0 {
+		timeout = j.Timeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	err = j.Run(runCtx)
+	metrics.JobDuration.WithLabelValues(j.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		logs.Logger.Errorf("scheduler| %s failed: %v", j.Name, err)
+		metrics.JobRuns.WithLabelValues(j.Name, "error").Inc()
+		return
+	}
+	metrics.JobRuns.WithLabelValues(j.Name, "ok").Inc()
+}
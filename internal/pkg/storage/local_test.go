@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalPathRejectsEscapes(t *testing.T) {
+	l := &Local{dir: t.TempDir()}
+	for _, key := range []string{"../x", "a/../../x", "..", "", ".", "cards/../../etc/passwd"} {
+		if p, err := l.path(key); err == nil {
+			t.Errorf("path(%q) = %q, want an error", key, p)
+		}
+	}
+	for _, key := range []string{"x", "cards/1/2", "a/../b"} {
+		p, err := l.path(key)
+		if err != nil {
+			t.Errorf("path(%q) error: %v", key, err)
+			continue
+		}
+		if !strings.HasPrefix(p, l.dir+string(filepath.Separator)) {
+			t.Errorf("path(%q) = %q is outside %q", key, p, l.dir)
+		}
+	}
+}
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	l := &Local{dir: t.TempDir()}
+	if err := l.Put(ctx, "cards/c/a", strings.NewReader("hello"), -1, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := l.Open(ctx, "cards/c/a")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "hello" {
+		t.Errorf("content = %q, want hello", b)
+	}
+	if err := l.Delete(ctx, "cards/c/a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := l.Delete(ctx, "cards/c/a"); err != nil {
+		t.Errorf("Delete of a missing object: %v", err)
+	}
+	if _, err := l.Open(ctx, "cards/c/a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete = %v, want ErrNotFound", err)
+	}
+}
+
+type failingReader struct{ n int }
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.n == 0 {
+		return 0, errors.New("client went away")
+	}
+	f.n--
+	return copy(p, "partial"), nil
+}
+
+func TestLocalFailedPutStoresNothing(t *testing.T) {
+	l := &Local{dir: t.TempDir()}
+	if err := l.Put(context.Background(), "cards/c/a", &failingReader{n: 3}, -1, ""); err == nil {
+		t.Fatal("Put from a failing reader succeeded")
+	}
+	entries, err := os.ReadDir(filepath.Join(l.dir, "cards", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("left %d files behind, e.g. %s", len(entries), entries[0].Name())
+	}
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3PartSize bounds the memory used by an upload of unknown size: minio-go buffers one part
+const s3PartSize = 16 << 20
+
+// S3 keeps objects in a bucket of any S3-compatible store (AWS S3, MinIO)
+type S3 struct {
+	client  *minio.Client
+	presign *minio.Client // the same store addressed by PublicEndpoint
+	bucket  string
+}
+
+func NewS3(cfg Config) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage: s3 driver needs an endpoint and a bucket")
+	}
+	client, err := newMinio(cfg, cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	s := &S3{client: client, presign: client, bucket: cfg.Bucket}
+	if cfg.PublicEndpoint != "" && cfg.PublicEndpoint != cfg.Endpoint {
+		if s.presign, err = newMinio(cfg, cfg.PublicEndpoint); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func newMinio(cfg Config, endpoint string) (*minio.Client, error) {
+	lookup := minio.BucketLookupAuto
+	if cfg.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	region := cfg.Region
+	if region == "" {
+		// with a region set, presigning does not look up the bucket location over the network
+		region = "us-east-1"
+	}
+	c, err := minio.New(endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: s3 endpoint %s: %w", endpoint, err)
+	}
+	return c, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	// a failed upload is aborted by minio-go, so no partial object remains
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    s3PartSize,
+	})
+	return err
+}
+
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy; Stat makes the request and reports a missing key
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return nil
+	}
+	return err
+}
+
+// PresignGet returns a URL valid for ttl that downloads the object as filename
+func (s *S3) PresignGet(ctx context.Context, key, filename, contentType string, ttl time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	if contentType != "" {
+		params.Set("response-content-type", contentType)
+	}
+	u, err := s.presign.PresignedGetObject(ctx, s.bucket, key, ttl, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+var _ Presigner = (*S3)(nil)
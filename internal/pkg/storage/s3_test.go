@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestS3RoundTrip runs against a real S3-compatible store, e.g. the MinIO of docker compose:
+//
+//	STORAGE_TEST_S3_ENDPOINT=localhost:9000 go test ./internal/pkg/storage
+//
+// The bucket (STORAGE_TEST_S3_BUCKET, default interview-tracker) must exist.
+func TestS3RoundTrip(t *testing.T) {
+	endpoint := os.Getenv("STORAGE_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("STORAGE_TEST_S3_ENDPOINT not set")
+	}
+	s, err := NewS3(Config{
+		Endpoint:  endpoint,
+		Bucket:    envOr("STORAGE_TEST_S3_BUCKET", "interview-tracker"),
+		AccessKey: envOr("STORAGE_TEST_S3_ACCESS_KEY", "minioadmin"),
+		SecretKey: envOr("STORAGE_TEST_S3_SECRET_KEY", "minioadmin"),
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	key := "test/" + uuid.NewString()
+	t.Cleanup(func() { _ = s.Delete(context.Background(), key) })
+
+	if err := s.Put(ctx, key, strings.NewReader("hello"), -1, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := s.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "hello" {
+		t.Errorf("content = %q, want hello", b)
+	}
+
+	u, err := s.PresignGet(ctx, key, "hello.txt", "text/plain", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignGet: %v", err)
+	}
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatalf("GET presigned URL: %v", err)
+	}
+	b, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(b) != "hello" {
+		t.Errorf("presigned GET = %d %q, want 200 hello", resp.StatusCode, b)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); !strings.Contains(cd, "hello.txt") {
+		t.Errorf("Content-Disposition = %q, want the file name", cd)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete = %v, want ErrNotFound", err)
+	}
+	if err := s.Put(ctx, key+"-failed", &failingReader{n: 2}, -1, ""); err == nil {
+		t.Error("Put from a failing reader succeeded")
+	}
+	if _, err := s.Open(ctx, key+"-failed"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("failed Put left an object: %v", err)
+	}
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,61 @@
+// Package storage keeps the files attached to cards. Usecases depend on the Storage interface;
+// the driver (local filesystem or S3-compatible object storage) is picked by configuration.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by Open when there is no object under the key
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores objects by key. Keys are made by the caller from ids, e.g. cards/<card>/<attachment>.
+type Storage interface {
+	// Put streams r to key; size is -1 when not known in advance. On error nothing is stored.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the object's content; the caller closes it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Presigner is implemented by drivers whose objects clients can download directly (S3 presigned
+// URLs). Objects of other drivers are streamed by the service.
+type Presigner interface {
+	PresignGet(ctx context.Context, key, filename, contentType string, ttl time.Duration) (string, error)
+}
+
+// Config selects and configures the driver
+type Config struct {
+	Driver string // local, s3
+	Dir    string // local: root directory
+	// s3: host:port of the API, e.g. minio:9000 or s3.ap-southeast-1.amazonaws.com
+	Endpoint string
+	// s3: host:port used in presigned URLs when clients reach the store under another name
+	// than the service does (e.g. localhost:9000 for MinIO in docker compose)
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string `json:"-"`
+	UseSSL         bool
+	PathStyle      bool // bucket in the path instead of the host name; MinIO needs it
+}
+
+// New returns the storage for cfg.Driver. It does not contact the store.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("storage: local driver needs a directory")
+		}
+		return &Local{dir: cfg.Dir}, nil
+	case "s3":
+		return NewS3(cfg)
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+}
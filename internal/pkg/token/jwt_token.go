@@ -48,3 +48,48 @@ func SignAccess(refID string, ttl time.Duration) (string, error) {
 
 	return token.SignedString(privateKey)
 }
+
+// download tokens let a URL fetch one attachment without a session, until they expire
+const downloadAudience = "attachment-download"
+
+// SignDownload returns a token for downloading the attachment, valid for ttl
+func SignDownload(attachmentID string, ttl time.Duration) (string, error) {
+	if config.EnvConfig == nil || config.EnvConfig.JWTKeys.Private == nil {
+		return "", errors.New("jwt private key not loaded")
+	}
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": attachmentID,
+		"aud": downloadAudience,
+		"exp": now.Add(ttl).Unix(),
+		"iat": now.Unix(),
+		"iss": "interview-tracker",
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(config.EnvConfig.JWTKeys.Private)
+}
+
+// ParseDownload verifies a token from SignDownload and returns the attachment id
+func ParseDownload(tokenStr string) (string, error) {
+	if config.EnvConfig == nil || config.EnvConfig.JWTKeys.Public == nil {
+		return "", errors.New("jwt public key not loaded")
+	}
+	claims := jwt.MapClaims{}
+	t, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return config.EnvConfig.JWTKeys.Public, nil
+	})
+	if err != nil || !t.Valid {
+		return "", errors.New("invalid download token")
+	}
+	// exp is checked by Valid; a token without one never expires, so require it
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) || !claims.VerifyAudience(downloadAudience, true) || !claims.VerifyIssuer("interview-tracker", true) {
+		return "", errors.New("invalid download token")
+	}
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return "", errors.New("invalid download token")
+	}
+	return sub, nil
+}
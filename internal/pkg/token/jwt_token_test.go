@@ -0,0 +1,81 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"interview-tracker/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func useKeys(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := config.EnvConfig
+	config.EnvConfig = &config.Config{JWTKeys: config.JWTKeys{Private: key, Public: &key.PublicKey}}
+	t.Cleanup(func() { config.EnvConfig = prev })
+	return key
+}
+
+func TestDownloadToken(t *testing.T) {
+	key := useKeys(t)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(k *rsa.PrivateKey, claims jwt.MapClaims) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	valid, err := SignDownload("a1", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, err := SignAccess("ref", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := SignDownload("a1", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Minute).Unix()
+	tests := []struct {
+		name  string
+		token string
+		want  string // "" = rejected
+	}{
+		{"valid", valid, "a1"},
+		{"access token", access, ""},
+		{"expired", expired, ""},
+		{"no expiry", sign(key, jwt.MapClaims{"sub": "a1", "aud": downloadAudience, "iss": "interview-tracker"}), ""},
+		{"other audience", sign(key, jwt.MapClaims{"sub": "a1", "aud": "other", "iss": "interview-tracker", "exp": exp}), ""},
+		{"other issuer", sign(key, jwt.MapClaims{"sub": "a1", "aud": downloadAudience, "iss": "someone", "exp": exp}), ""},
+		{"other key", sign(other, jwt.MapClaims{"sub": "a1", "aud": downloadAudience, "iss": "interview-tracker", "exp": exp}), ""},
+		{"tampered", valid[:len(valid)-2] + "xx", ""},
+		{"garbage", "not-a-token", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDownload(tt.token)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("ParseDownload accepted the token for %q", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseDownload = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	"interview-tracker/internal/pkg/buildinfo"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options mirrors config.TracingConfig so this package does not depend on config.
+type Options struct {
+	ServiceName string
+	Environment string
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+}
+
+// Init installs the global tracer provider (OTLP/HTTP exporter, batch span processor)
+// and the W3C trace-context + baggage propagator.
+// The returned func flushes pending spans and must be called on shutdown.
+func Init(ctx context.Context, o Options) (func(context.Context) error, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(o.ServiceName),
+		semconv.ServiceVersion(buildinfo.Version),
+		semconv.DeploymentEnvironment(o.Environment),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
+
+// TraceID returns the trace id of the span in ctx ("" when there is no valid span)
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// GinMiddleware exposes the current trace id as the X-Trace-ID response header and
+// as "traceID" in the gin context, so logs and error responses can reference it.
+// Register it after otelgin so the request span already exists.
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if id := TraceID(c.Request.Context()); id != "" {
+			c.Set("traceID", id)
+			c.Header("X-Trace-ID", id)
+		}
+		c.Next()
+	}
+}
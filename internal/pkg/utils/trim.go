@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TrimStrings trims surrounding whitespace from every string (and *string, []string)
+// field of the struct ptr points to, recursing into nested structs.
+// Fields tagged `trim:"false"` (e.g. passwords, Markdown source) are left untouched.
+func TrimStrings(ptr any) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return
+	}
+	trimValue(v.Elem())
+}
+
+func trimValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			trimValue(v.Elem())
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(strings.TrimSpace(v.String()))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			trimValue(v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() || f.Tag.Get("trim") == "false" {
+				continue
+			}
+			trimValue(v.Field(i))
+		}
+	}
+}
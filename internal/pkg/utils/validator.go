@@ -1,24 +1,60 @@
 package utils
 
 import (
+	"errors"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
-func ValidateRequest(request interface{}) map[string]string {
-	if err := newValidator().Struct(request); err != nil {
-		return validatorErrors(err)
+// ValidateRequest checks the `binding` tags of a request struct and returns
+// a field -> message map (keyed by the JSON name, messages in lang), or nil when it is valid.
+func ValidateRequest(request interface{}, lang string) map[string]string {
+	if err := Validator().Struct(request); err != nil {
+		return validatorErrors(err, lang)
 	}
 	return nil
 }
 
+var (
+	validatorOnce sync.Once
+	validate      *validator.Validate
+)
+
+// Validator returns the shared validator used for every request model
+func Validator() *validator.Validate {
+	validatorOnce.Do(func() { validate = newValidator() })
+	return validate
+}
+
 // newValidator func for creating a new validator for model fields.
 func newValidator() *validator.Validate {
 	validate := validator.New()
+	validate.SetTagName("binding")
+	// report errors by the json name clients actually send
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			name, _, _ = strings.Cut(f.Tag.Get("form"), ",")
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	_ = validate.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		return validateFuture(fl)
+	})
 	_ = validate.RegisterValidation("notEmpty", func(fl validator.FieldLevel) bool {
 		return notEmpty(fl)
 	})
@@ -31,20 +67,64 @@ func newValidator() *validator.Validate {
 	_ = validate.RegisterValidation("uuid", func(fl validator.FieldLevel) bool {
 		return ValidateUUID(fl)
 	})
+	_ = validate.RegisterValidation("emoji", func(fl validator.FieldLevel) bool {
+		return IsEmoji(fl.Field().String())
+	})
 
 	return validate
 }
 
 // validatorErrors func for showing validation errors for each invalid field.
-func validatorErrors(err error) map[string]string {
+func validatorErrors(err error, lang string) map[string]string {
 	fields := map[string]string{}
-	for _, err := range err.(validator.ValidationErrors) {
-		fields[err.Field()] = err.Field() + " " + err.Tag() + " " + err.Param()
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		fields["_"] = i18n.T(lang, "validation.invalid", map[string]string{"param": err.Error()})
+		return fields
+	}
+	for _, e := range verrs {
+		fields[e.Field()] = fieldMessage(e, lang)
 	}
 
 	return fields
 }
 
+// fieldMessage looks up "validation.<tag>" (or "validation.<tag>.string|slice" for
+// length rules) in the i18n catalog; unknown tags fall back to validation.invalid.
+func fieldMessage(e validator.FieldError, lang string) string {
+	param := e.Param()
+	if e.Tag() == "oneof" {
+		param = strings.ReplaceAll(param, " ", ", ")
+	}
+	params := map[string]string{"param": param}
+
+	key := "validation." + e.Tag()
+	if e.Tag() == "min" || e.Tag() == "max" {
+		switch e.Kind() {
+		case reflect.String:
+			key += ".string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			key += ".slice"
+		}
+	}
+	if msg, ok := i18n.Lookup(lang, key, params); ok {
+		return msg
+	}
+	return i18n.T(lang, "validation.invalid", map[string]string{"param": strings.TrimSpace(e.Tag() + " " + e.Param())})
+}
+
+// futureSkew tolerates small clock differences between client and server
+const futureSkew = time.Minute
+
+// validateFuture rejects times in the past (for scheduling fields)
+func validateFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	return t.After(time.Now().Add(-futureSkew))
+}
+
 // notEmpty supports string, json, array and map types
 func notEmpty(fl validator.FieldLevel) bool {
 	field := fl.Field()
@@ -77,6 +157,33 @@ func validateEnglishAlphabet(fl validator.FieldLevel) bool {
 	return match
 }
 
+// IsEmoji reports whether s is a single emoji, including skin tones, flags, keycaps
+// and ZWJ sequences (e.g. 👍🏽, 🇹🇭, 1️⃣, 👩‍💻)
+func IsEmoji(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 || len(runes) > 10 {
+		return false
+	}
+	pictographs := 0
+	for i, r := range runes {
+		switch {
+		case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, flags (regional indicators), skin tones
+			r >= 0x2600 && r <= 0x27BF, // misc symbols, dingbats
+			r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF,
+			r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+			r >= 0x2194 && r <= 0x21AA, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+			pictographs++
+		case r == 0x200D, r == 0xFE0F, r == 0x20E3, r >= 0xE0020 && r <= 0xE007F:
+			// joiner, emoji presentation, keycap, tag sequences
+		case i == 0 && (r == '#' || r == '*' || (r >= '0' && r <= '9')) && len(runes) > 1:
+			pictographs++ // keycap base
+		default:
+			return false
+		}
+	}
+	return pictographs > 0
+}
+
 func ValidateUUID(fl validator.FieldLevel) bool {
 	field := fl.Field()
 	if field.Kind() == reflect.String {
@@ -0,0 +1,123 @@
+// Package webhook signs and sends outbound webhook requests.
+//
+// Every request is a JSON POST with these headers:
+//
+//	Webhook-Id:        delivery id (stable across retries; de-duplicate on the event id in the body)
+//	Webhook-Event:     event type, e.g. card.status_changed
+//	Webhook-Timestamp: unix seconds when the request was signed
+//	Webhook-Signature: v1=<hex HMAC-SHA256 of "<timestamp>.<body>" with the webhook secret>
+//
+// Receivers should recompute the signature and reject old timestamps to stop replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	HeaderID        = "Webhook-Id"
+	HeaderEvent     = "Webhook-Event"
+	HeaderTimestamp = "Webhook-Timestamp"
+	HeaderSignature = "Webhook-Signature"
+
+	userAgent = "interview-tracker-webhooks/1"
+)
+
+// Sign returns the Webhook-Signature value for body sent at ts
+func Sign(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender returns a sender whose requests give up after timeout. Redirects are not
+// followed: a 3xx counts as a failed delivery, so a hook cannot bounce us elsewhere.
+//
+// Connections to loopback, private, link-local and unspecified addresses are refused
+// unless they fall in allowed (meant for local development). The check runs on the
+// address actually dialled, so a hostname that later resolves inward is still refused.
+func NewSender(timeout time.Duration, allowed []netip.Prefix) *Sender {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl(allowed),
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be the address checked instead of the webhook host
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Sender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: otelhttp.NewTransport(transport),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// cgnat is the shared address space of RFC 6598, which netip does not count as private
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+func dialControl(allowed []netip.Prefix) func(network, address string, c syscall.RawConn) error {
+	return func(_, address string, _ syscall.RawConn) error {
+		ap, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("webhook: refusing to connect to %s: %w", address, err)
+		}
+		ip := ap.Addr().Unmap()
+		for _, p := range allowed {
+			if p.Contains(ip) {
+				return nil
+			}
+		}
+		if !ip.IsGlobalUnicast() || ip.IsPrivate() || cgnat.Contains(ip) {
+			return fmt.Errorf("webhook: refusing to connect to non-public address %s", ip)
+		}
+		return nil
+	}
+}
+
+// Send POSTs body and returns the response status. err is set for transport errors only;
+// callers decide which statuses count as success.
+func (s *Sender) Send(ctx context.Context, url, secret, deliveryID, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, deliveryID)
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	// drain a little so the connection can be reused; the body itself is not needed
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// printf '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	const want = "v1=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"
+	if got := Sign("secret", time.Unix(1700000000, 0), []byte(`{"a":1}`)); got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+	if got := Sign("other", time.Unix(1700000000, 0), []byte(`{"a":1}`)); got == want {
+		t.Fatal("signature does not depend on the secret")
+	}
+	if got := Sign("secret", time.Unix(1700000001, 0), []byte(`{"a":1}`)); got == want {
+		t.Fatal("signature does not depend on the timestamp")
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		allowed []string
+		ok      bool
+	}{
+		{"public v4", "93.184.216.34:443", nil, true},
+		{"public v6", "[2606:2800:220:1::1]:443", nil, true},
+		{"loopback", "127.0.0.1:80", nil, false},
+		{"loopback v6", "[::1]:80", nil, false},
+		{"unspecified", "0.0.0.0:80", nil, false},
+		{"rfc1918 10/8", "10.1.2.3:80", nil, false},
+		{"rfc1918 172.16/12", "172.16.0.1:80", nil, false},
+		{"rfc1918 192.168/16", "192.168.1.1:80", nil, false},
+		{"link-local metadata", "169.254.169.254:80", nil, false},
+		{"link-local v6", "[fe80::1]:80", nil, false},
+		{"unique local v6", "[fd00::1]:80", nil, false},
+		{"cgnat", "100.64.0.1:80", nil, false},
+		{"cgnat upper end", "100.127.255.254:80", nil, false},
+		{"just outside cgnat", "100.128.0.1:80", nil, true},
+		{"v4-mapped loopback", "[::ffff:127.0.0.1]:80", nil, false},
+		{"v4-mapped private", "[::ffff:10.0.0.1]:80", nil, false},
+		{"multicast", "224.0.0.1:80", nil, false},
+		{"not an address", "example.com:80", nil, false},
+		{"loopback allowed", "127.0.0.1:8080", []string{"127.0.0.0/8"}, true},
+		{"v4-mapped loopback allowed", "[::ffff:127.0.0.1]:8080", []string{"127.0.0.1/32"}, true},
+		{"private allowed", "10.1.2.3:80", []string{"10.0.0.0/8"}, true},
+		{"other private still refused", "192.168.1.1:80", []string{"10.0.0.0/8"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var allowed []netip.Prefix
+			for _, p := range tt.allowed {
+				allowed = append(allowed, netip.MustParsePrefix(p))
+			}
+			err := dialControl(allowed)("tcp", tt.address, nil)
+			if (err == nil) != tt.ok {
+				t.Fatalf("dialControl(%s) err = %v, want ok=%v", tt.address, err, tt.ok)
+			}
+		})
+	}
+}
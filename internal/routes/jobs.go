@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/pkg/events"
+	"interview-tracker/internal/pkg/lifecycle"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/notify"
+	"interview-tracker/internal/pkg/realtime"
+	"interview-tracker/internal/pkg/scheduler"
+	"interview-tracker/internal/usecases"
+)
+
+// Jobs starts the background jobs. Every replica runs the loops;
+// a Redis key per slot makes only one of them fire each run.
+// SCHEDULER_ENABLED only switches the reminder jobs: the outbox relay and webhook
+// dispatch always run, since webhooks and the board event stream depend on them.
+func Jobs(bg *lifecycle.Group) {
+	r := scheduler.New(config.Rdb)
+
+	cfg := config.EnvConfig.Scheduler
+	if cfg.Enabled {
+		notifier, err := notify.New(cfg.Notifier, cfg.NotifyFile)
+		if err != nil {
+			panic(err) // config.validate already checked the driver
+		}
+		uc := usecases.NewReminderUsecase(repositories.NewNotificationRepo(config.DB), notifier, cfg.ReminderLeads, cfg.StaleAfter)
+		r.Add(scheduler.Job{Name: "interview-reminders", Interval: cfg.ReminderInterval, Run: uc.SendReminders})
+		r.Add(scheduler.Job{Name: "stale-cards", Interval: cfg.StaleInterval, Run: uc.AlertStaleCards})
+	} else {
+		logs.Logger.Printf("scheduler| reminders disabled")
+	}
+
+	hooks := usecases.NewWebhookUsecase(repositories.NewWebhookRepo(config.DB), config.EnvConfig.Webhook)
+	r.Add(scheduler.Job{Name: "webhook-dispatch", Interval: config.EnvConfig.Webhook.DispatchInterval, Run: hooks.Dispatch})
+
+	ob := config.EnvConfig.Outbox
+	relay := usecases.NewOutboxRelay(repositories.NewTransactor(config.DB), repositories.NewOutboxRepo(config.DB), outboxSinks(ob, hooks), ob.Retention)
+	// the relay lock keeps a run that outlives its slot from overlapping the next one
+	r.Add(scheduler.Job{Name: "outbox-relay", Interval: ob.RelayInterval, Timeout: ob.RelayTimeout, Run: relay.Relay})
+	r.Add(scheduler.Job{Name: "outbox-cleanup", Interval: time.Hour, Run: relay.Cleanup})
+	r.Start(bg)
+}
+
+// outboxSinks builds the sinks named in OUTBOX_SINKS, in that order
+func outboxSinks(cfg config.OutboxConfig, hooks *usecases.WebhookUsecase) []events.NamedSink {
+	sinks := make([]events.NamedSink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		var s events.Sink
+		switch name {
+		case "webhook":
+			s = hooks
+		case "realtime":
+			s = realtime.NewSink(config.Rdb, config.EnvConfig.Realtime.Stream, config.EnvConfig.Realtime.StreamMaxLen)
+		case "redis":
+			s = events.NewRedisStream(config.Rdb, cfg.RedisStream, cfg.RedisStreamMax)
+		case "log":
+			s = events.LogSink{}
+		}
+		sinks = append(sinks, events.NamedSink{Name: name, Sink: s})
+	}
+	return sinks
+}
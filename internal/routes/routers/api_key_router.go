@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ApiKey(r *gin.RouterGroup) {
+	db := config.DB
+	uc := usecases.NewApiKeyUsecase(repositories.NewApiKeyRepo(db), repositories.NewUserRepo(db))
+	h := handlers.NewApiKeyHandler(uc)
+
+	g := r.Group("/internal/v1/api-keys", middleware.Authorize("api_key_manage"))
+	{
+		g.POST("", h.Create)
+		g.GET("", h.List)
+		g.DELETE("/:id", h.Revoke)
+	}
+}
@@ -0,0 +1,37 @@
+package routers
+
+import (
+	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/storage"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+const attachmentDownloadPath = "/attachments/download"
+
+func Attachment(r *gin.RouterGroup) {
+	db := config.DB
+	cfg := config.EnvConfig.Attachment
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		panic(err) // config.validate already checked the driver
+	}
+	downloadURL := config.EnvConfig.PublicBaseURL + r.BasePath() + attachmentDownloadPath
+	uc := usecases.NewAttachmentUsecase(repositories.NewAttachmentRepo(db), repositories.NewCardRepo(db), repositories.NewTransactor(db), repositories.NewOutboxRepo(db), store, cfg, downloadURL)
+	h := handlers.NewAttachmentHandler(uc, cfg.TransferTimeout)
+
+	// download links are opened by the browser without headers, so they are authenticated by their token only
+	r.GET(attachmentDownloadPath, h.Download)
+
+	g := r.Group("/authen")
+	{
+		g.POST("/cards/:id/attachments", middleware.Authorize("attachment_add"), h.Upload)
+		g.GET("/cards/:id/attachments", middleware.Authorize("attachment_view"), h.List)
+		g.GET("/cards/attachments/:attachmentId/url", middleware.Authorize("attachment_view"), h.DownloadURL)
+		g.DELETE("/cards/attachments/:attachmentId", middleware.AuthorizeAny("attachment_add", "attachment_delete"), h.Delete)
+	}
+}
@@ -19,6 +19,14 @@ func Auth(r *gin.RouterGroup) {
 	r.POST("/internal/v1/auth/login", authHandler.Login)
 	r.POST("/internal/v1/auth/refresh", authHandler.Refresh)
 
+	// single sign-on (เปิดใช้เมื่อกำหนด OIDC_ISSUER_URL + OIDC_CLIENT_ID)
+	if oidcCfg := config.EnvConfig.OIDC; oidcCfg.Enabled() {
+		roleRepo := repositories.NewRoleRepo(db)
+		ssoHandler := handlers.NewSSOHandler(usecases.NewSSOUsecase(userRepo, roleRepo, rdb, oidcCfg))
+		r.GET("/internal/v1/auth/sso/login", ssoHandler.Login)
+		r.GET("/internal/v1/auth/sso/callback", ssoHandler.Callback)
+	}
+
 	// protected (ต้องใช้ Bearer token)
 	authenGroup := r.Group("/", middleware.Authn())
 	authenGroup.POST("/internal/v1/auth/logout", authHandler.Logout)
@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/lifecycle"
+	"interview-tracker/internal/pkg/realtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Board(r *gin.RouterGroup, bg *lifecycle.Group) {
+	hub := realtime.NewHub(config.Rdb, config.EnvConfig.Realtime.Stream)
+	bg.Go("realtime-hub", hub.Run)
+	h := handlers.NewBoardHandler(hub, bg.Draining())
+
+	// EventSource cannot send headers, so the token may come in the query string
+	r.GET("/authen/board/events", middleware.TokenFromQuery(), middleware.Authorize("card_view"), h.Events)
+}
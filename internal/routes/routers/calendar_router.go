@@ -0,0 +1,31 @@
+package routers
+
+import (
+	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+const calendarFeedPath = "/calendar/feed.ics"
+
+func Calendar(r *gin.RouterGroup) {
+	db := config.DB
+	feedURL := config.EnvConfig.PublicBaseURL + r.BasePath() + calendarFeedPath
+	uc := usecases.NewCalendarUsecase(repositories.NewCardRepo(db), repositories.NewCalendarFeedRepo(db), repositories.NewUserRepo(db), feedURL)
+	h := handlers.NewCalendarHandler(uc)
+
+	// calendar clients cannot send headers, so the feed is authenticated by its token only
+	r.GET(calendarFeedPath, h.Feed)
+
+	g := r.Group("/authen")
+	{
+		g.GET("/cards/:id/ics", middleware.Authorize("card_view"), h.CardICS)
+		g.POST("/calendar/feeds", middleware.Authorize("card_view"), h.CreateFeed)
+		g.GET("/calendar/feeds", middleware.Authorize("card_view"), h.ListFeeds)
+		g.DELETE("/calendar/feeds/:id", middleware.Authorize("card_view"), h.RevokeFeed)
+	}
+}
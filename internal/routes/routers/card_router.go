@@ -5,14 +5,19 @@ import (
 	"interview-tracker/internal/adapters/repositories"
 	"interview-tracker/internal/config"
 	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/notify"
 	"interview-tracker/internal/usecases"
 
 	"github.com/gin-gonic/gin"
 )
 
 func Card(r *gin.RouterGroup) {
-	repo := repositories.NewCardRepo(config.DB)
-	uc := usecases.NewCardUsecase(repo)
+	db := config.DB
+	notifier, err := notify.New(config.EnvConfig.Scheduler.Notifier, config.EnvConfig.Scheduler.NotifyFile)
+	if err != nil {
+		panic(err) // config.validate already checked the driver
+	}
+	uc := usecases.NewCardUsecase(repositories.NewCardRepo(db), repositories.NewTransactor(db), repositories.NewOutboxRepo(db), notifier)
 	h := handlers.NewCardHandler(uc)
 
 	// ทั้งหมดอยู่ใต้ /interview-tracker/authen (ติด Authn อยู่แล้ว)
@@ -21,17 +26,25 @@ func Card(r *gin.RouterGroup) {
 		// list/detail (view)
 		g.GET("/cards", middleware.Authorize("card_view"), h.List)
 		g.GET("/cards/:id", middleware.Authorize("card_view"), h.Detail)
+		g.GET("/card-statuses", middleware.Authorize("card_view"), h.ListStatuses)
+		g.GET("/calendar", middleware.Authorize("card_view"), h.Calendar)
+		g.GET("/board", middleware.Authorize("card_view"), h.Board)
 
 		// create/update/status (edit)
 		g.POST("/cards", middleware.Authorize("card_add"), h.Create)
 		g.PATCH("/cards/:id", middleware.Authorize("card_edit"), h.Update)
 		g.PATCH("/cards/:id/status", middleware.Authorize("card_edit"), h.UpdateStatus)
+		g.POST("/cards/:id/move", middleware.Authorize("card_edit"), h.Move)
 
 		// comments
 		g.POST("/cards/:id/comments", middleware.Authorize("comment_add"), h.AddComment)
 		g.PATCH("/cards/comments/:commentId", middleware.Authorize("comment_edit"), h.UpdateComment)
-		g.DELETE("/cards/comments/:commentId", middleware.Authorize("comment_edit"), h.DeleteComment)
+		g.DELETE("/cards/comments/:commentId", middleware.AuthorizeAny("comment_edit", "comment_delete"), h.DeleteComment)
+		g.POST("/cards/comments/:commentId/restore", middleware.AuthorizeAny("comment_edit", "comment_delete"), h.RestoreComment)
 		g.GET("/cards/:id/comments", middleware.Authorize("comment_view"), h.ListComments)
+		g.GET("/cards/comments/:commentId/edits", middleware.Authorize("comment_view"), h.ListCommentEdits)
+		g.POST("/cards/comments/:commentId/reactions", middleware.Authorize("comment_add"), h.React)
+		g.DELETE("/cards/comments/:commentId/reactions/:emoji", middleware.Authorize("comment_add"), h.Unreact)
 
 		// History
 		g.POST("/cards/:id/keep", middleware.Authorize("card_edit"), h.Keep)
@@ -2,10 +2,18 @@ package routers
 
 import (
 	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/usecases"
 
 	"github.com/gin-gonic/gin"
 )
 
 func Health(r *gin.RouterGroup) {
+	cfg := config.EnvConfig
+	uc := usecases.NewHealthUsecase(config.DB, config.Rdb, cfg.JWTKeys, cfg.Database.MigrationsPath, cfg.HealthCheckTimeout)
+	h := handlers.NewHealthHandler(uc)
+
 	r.GET("/health", handlers.GetHealth)
+	r.GET("/health/live", h.Live)
+	r.GET("/health/ready", h.Ready)
 }
@@ -18,4 +18,5 @@ func User(r *gin.RouterGroup) {
 	r.POST("/internal/v1/users/create", userHandler.Create)
 	r.GET("/internal/v1/users/details/:userId", userHandler.GetById)
 	r.GET("/internal/v1/users/role-list", userHandler.GetListRole)
+	r.GET("/internal/v1/users/permission-list", userHandler.GetListPermission)
 }
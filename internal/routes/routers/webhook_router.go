@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"interview-tracker/internal/adapters/handlers"
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Webhook(r *gin.RouterGroup) {
+	uc := usecases.NewWebhookUsecase(repositories.NewWebhookRepo(config.DB), config.EnvConfig.Webhook)
+	h := handlers.NewWebhookHandler(uc)
+
+	g := r.Group("/internal/v1/webhooks", middleware.Authorize("webhook_manage"))
+	{
+		g.POST("", h.Create)
+		g.GET("", h.List)
+		g.GET("/:id", h.Detail)
+		g.PATCH("/:id", h.Update)
+		g.DELETE("/:id", h.Delete)
+		g.GET("/:id/deliveries", h.ListDeliveries)
+		g.POST("/:id/deliveries/:deliveryId/replay", h.Replay)
+	}
+}
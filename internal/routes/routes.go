@@ -1,19 +1,29 @@
 package routes
 
 import (
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
 	"interview-tracker/internal/middleware"
+	"interview-tracker/internal/pkg/lifecycle"
 	"interview-tracker/internal/routes/routers"
-	"time"
+	"interview-tracker/internal/usecases"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Listen(eg *gin.Engine) {
+func Listen(bg *lifecycle.Group, eg *gin.Engine) {
+	middleware.UseApiKeys(usecases.NewApiKeyUsecase(repositories.NewApiKeyRepo(config.DB), repositories.NewUserRepo(config.DB)))
+
 	interviewTrackerGroup := eg.Group("/interview-tracker")
-	interviewTrackerGroup.Use(middleware.RateLimitPerMinute(60, 20, 10*time.Minute))
+	interviewTrackerGroup.Use(middleware.RateLimit(bg, config.EnvConfig.RateLimit))
 
 	routers.Health(interviewTrackerGroup)
 	routers.User(interviewTrackerGroup)
 	routers.Auth(interviewTrackerGroup)
 	routers.Card(interviewTrackerGroup)
+	routers.Attachment(interviewTrackerGroup)
+	routers.Calendar(interviewTrackerGroup)
+	routers.Board(interviewTrackerGroup, bg)
+	routers.ApiKey(interviewTrackerGroup)
+	routers.Webhook(interviewTrackerGroup)
 }
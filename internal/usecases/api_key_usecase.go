@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/models/api_key_models"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/token"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const defaultApiKeyTTLDays = 90
+
+type ApiKeyUsecase struct {
+	repo     repositories.ApiKeyRepository
+	userRepo repositories.UserRepository
+}
+
+func NewApiKeyUsecase(r repositories.ApiKeyRepository, userRepo repositories.UserRepository) *ApiKeyUsecase {
+	return &ApiKeyUsecase{repo: r, userRepo: userRepo}
+}
+
+// Issue creates a key limited to a subset of the issuer's own permissions.
+// The raw key is returned once; only its sha256 is stored.
+func (uc *ApiKeyUsecase) Issue(ctx context.Context, actor uuid.UUID, actorPerms []string, req api_key_models.CreateApiKeyReq) (*api_key_models.CreateApiKeyResp, error) {
+	for _, p := range req.Permissions {
+		if !containsString(actorPerms, p) {
+			return nil, errs.Forbidden("cannot grant permission not held: "+p).WithCode("permission_not_held").WithParam("permission", p)
+		}
+	}
+	ttlDays := req.ExpiresInDays
+	if ttlDays <= 0 {
+		ttlDays = defaultApiKeyTTLDays
+	}
+
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	prefix := entities.ApiKeyPrefix + hex.EncodeToString(b)
+	secret, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	raw := prefix + "_" + secret
+
+	var txnDtm = time.Now()
+	key := &entities.ApiKey{
+		Name:        strings.TrimSpace(req.Name),
+		Prefix:      prefix,
+		KeyHash:     token.Sha256Hex(raw),
+		Permissions: pq.StringArray(req.Permissions),
+		ExpiresAt:   txnDtm.Add(days(ttlDays)),
+		IsActive:    true,
+		CreatedBy:   actor,
+		UpdatedBy:   actor,
+		CreatedAt:   txnDtm,
+		UpdatedAt:   txnDtm,
+	}
+	if err := uc.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &api_key_models.CreateApiKeyResp{
+		ID:          key.ID,
+		Name:        key.Name,
+		Key:         raw,
+		Prefix:      key.Prefix,
+		Permissions: key.Permissions,
+		ExpiresAt:   key.ExpiresAt,
+	}, nil
+}
+
+func (uc *ApiKeyUsecase) List(ctx context.Context) ([]*entities.ApiKey, error) {
+	return uc.repo.List(ctx, nil)
+}
+
+func (uc *ApiKeyUsecase) Revoke(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return dbErr(err, ErrApiKeyNotFound)
+	}
+	return uc.repo.Revoke(ctx, id, actor)
+}
+
+// Authenticate resolves a raw key and returns it with its effective permissions:
+// the key's permissions intersected with what its owner currently holds.
+// Unknown, revoked and expired keys give ErrInvalidApiKey; other errors are failures to check.
+func (uc *ApiKeyUsecase) Authenticate(ctx context.Context, raw string) (*entities.ApiKey, []string, error) {
+	if !strings.HasPrefix(raw, entities.ApiKeyPrefix) {
+		return nil, nil, ErrInvalidApiKey
+	}
+	key, err := uc.repo.GetByHash(ctx, token.Sha256Hex(raw))
+	if err != nil {
+		return nil, nil, dbErr(err, ErrInvalidApiKey)
+	}
+	now := time.Now()
+	if key.RevokedAt != nil || now.After(key.ExpiresAt) {
+		return nil, nil, ErrInvalidApiKey
+	}
+
+	ownerPerms, err := uc.userRepo.GetPermissionsByUserID(ctx, key.CreatedBy.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	perms := make([]string, 0, len(key.Permissions))
+	for _, p := range key.Permissions {
+		if containsString(ownerPerms, p) {
+			perms = append(perms, p)
+		}
+	}
+
+	_ = uc.repo.TouchLastUsed(ctx, key.ID, now)
+	return key, perms, nil
+}
+
+// Active reports whether the key can still be used, for connections that outlive the
+// request that authenticated it
+func (uc *ApiKeyUsecase) Active(ctx context.Context, id uuid.UUID) (bool, error) {
+	key, err := uc.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return key.IsActive && key.RevokedAt == nil && time.Now().Before(key.ExpiresAt), nil
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
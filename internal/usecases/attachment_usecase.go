@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/models/attachment_models"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/storage"
+	"interview-tracker/internal/pkg/token"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+)
+
+const (
+	// bytes read from the start of an upload to detect its type (the detector's default limit)
+	sniffLen = 3072
+	// stored file names are cut to this many characters, keeping the extension
+	maxFilenameLength = 255
+)
+
+// AttachmentUsecase stores card attachments: the content in storage, the metadata in the
+// database, with an outbox event for each change
+type AttachmentUsecase struct {
+	repo   repositories.AttachmentRepository
+	cards  repositories.CardRepository
+	tx     repositories.Transactor
+	outbox repositories.OutboxRepository
+	store  storage.Storage
+	cfg    config.AttachmentConfig
+	// downloadURL is the public download endpoint for drivers that cannot presign; the token is appended as ?token=
+	downloadURL string
+}
+
+func NewAttachmentUsecase(r repositories.AttachmentRepository, cards repositories.CardRepository, tx repositories.Transactor, outbox repositories.OutboxRepository, store storage.Storage, cfg config.AttachmentConfig, downloadURL string) *AttachmentUsecase {
+	return &AttachmentUsecase{repo: r, cards: cards, tx: tx, outbox: outbox, store: store, cfg: cfg, downloadURL: downloadURL}
+}
+
+// Limits are the upload limits, for clients to check a file before sending it
+func (uc *AttachmentUsecase) Limits() attachment_models.UploadLimits {
+	return attachment_models.UploadLimits{MaxBytes: uc.cfg.MaxBytes, AllowedTypes: uc.cfg.AllowedTypes}
+}
+
+// Upload streams r to storage as an attachment of the card, hashing it on the way. The type is
+// detected from the first bytes of the content; the size is counted while streaming, so an
+// oversized file is cut off at the limit and nothing is stored.
+func (uc *AttachmentUsecase) Upload(ctx context.Context, cardID, actor uuid.UUID, filename string, r io.Reader) (*entities.CardAttachment, error) {
+	card, err := uc.cards.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, dbErr(err, ErrCardNotFound)
+	}
+	if !card.IsActive {
+		return nil, ErrCardArchived
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, uc.uploadErr(err)
+	}
+	if n == 0 {
+		metrics.AttachmentUploads.WithLabelValues("rejected").Inc()
+		return nil, ErrAttachmentEmpty
+	}
+	mt := mimetype.Detect(head[:n])
+	if !uc.allowed(mt) {
+		metrics.AttachmentUploads.WithLabelValues("rejected").Inc()
+		return nil, ErrAttachmentType.WithParam("type", mt.String())
+	}
+
+	a := &entities.CardAttachment{
+		ID:          uuid.New(),
+		CardID:      cardID,
+		Filename:    cleanFilename(filename),
+		ContentType: mt.String(),
+		UploadedBy:  &actor,
+		CreatedAt:   time.Now(),
+	}
+	a.StorageKey = "cards/" + cardID.String() + "/" + a.ID.String()
+	body := &limitReader{r: io.MultiReader(bytes.NewReader(head[:n]), r), max: uc.cfg.MaxBytes}
+	hash := sha256.New()
+	if err := uc.store.Put(ctx, a.StorageKey, io.TeeReader(body, hash), -1, a.ContentType); err != nil {
+		if body.read > body.max {
+			err = errAttachmentTooLarge
+		}
+		return nil, uc.uploadErr(err)
+	}
+	a.SizeBytes = body.read
+	a.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Create(ctx, a); err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		return record(ctx, uc.outbox, EventAttachmentAdded, cardID, actor, map[string]any{"attachment": a})
+	})
+	if err != nil {
+		// nothing refers to the object without the row
+		uc.removeObject(ctx, a.StorageKey)
+		metrics.AttachmentUploads.WithLabelValues("failed").Inc()
+		return nil, err
+	}
+	metrics.AttachmentUploads.WithLabelValues("stored").Inc()
+	metrics.AttachmentBytes.Add(float64(a.SizeBytes))
+	return a, nil
+}
+
+// errAttachmentTooLarge marks a read cut off by limitReader (or by the request body limit)
+var errAttachmentTooLarge = errors.New("attachment too large")
+
+// uploadErr counts a failed upload and maps an oversized body to ErrAttachmentTooLarge
+func (uc *AttachmentUsecase) uploadErr(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.Is(err, errAttachmentTooLarge) || errors.As(err, &maxBytes) {
+		metrics.AttachmentUploads.WithLabelValues("too_large").Inc()
+		return ErrAttachmentTooLarge
+	}
+	metrics.AttachmentUploads.WithLabelValues("failed").Inc()
+	return err
+}
+
+func (uc *AttachmentUsecase) allowed(mt *mimetype.MIME) bool {
+	for _, t := range uc.cfg.AllowedTypes {
+		if mt.Is(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the card's attachments, newest first
+func (uc *AttachmentUsecase) List(ctx context.Context, cardID uuid.UUID) ([]*entities.CardAttachment, error) {
+	if _, err := uc.cards.GetByID(ctx, cardID); err != nil {
+		return nil, dbErr(err, ErrCardNotFound)
+	}
+	return uc.repo.ListByCard(ctx, cardID)
+}
+
+// DownloadURL returns a short-lived link to the file: a presigned URL when the storage can make
+// one, otherwise the service's download endpoint with a signed token
+func (uc *AttachmentUsecase) DownloadURL(ctx context.Context, id uuid.UUID) (*attachment_models.DownloadURLResp, error) {
+	a, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrAttachmentNotFound)
+	}
+	resp := &attachment_models.DownloadURLResp{ExpiresAt: time.Now().Add(uc.cfg.URLTTL).UTC()}
+	if p, ok := uc.store.(storage.Presigner); ok {
+		if resp.URL, err = p.PresignGet(ctx, a.StorageKey, a.Filename, a.ContentType, uc.cfg.URLTTL); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	tok, err := token.SignDownload(a.ID.String(), uc.cfg.URLTTL)
+	if err != nil {
+		return nil, err
+	}
+	resp.URL = uc.downloadURL + "?token=" + url.QueryEscape(tok)
+	return resp, nil
+}
+
+// Open checks a download token and opens the attachment it was issued for; the caller closes the reader
+func (uc *AttachmentUsecase) Open(ctx context.Context, tok string) (*entities.CardAttachment, io.ReadCloser, error) {
+	raw, err := token.ParseDownload(tok)
+	if err != nil {
+		return nil, nil, ErrInvalidDownloadToken
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, nil, ErrInvalidDownloadToken
+	}
+	a, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, dbErr(err, ErrAttachmentNotFound)
+	}
+	rc, err := uc.store.Open(ctx, a.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		logs.Ctx(ctx).Errorf("[attachment] %s: object %s is missing from storage", a.ID, a.StorageKey)
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+// Delete removes the attachment. The uploader may delete their own; a moderator
+// (attachment_delete) anyone's. The object is removed from storage after the row.
+func (uc *AttachmentUsecase) Delete(ctx context.Context, id, actor uuid.UUID, moderator bool) error {
+	a, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return dbErr(err, ErrAttachmentNotFound)
+	}
+	if !moderator && (a.UploadedBy == nil || *a.UploadedBy != actor) {
+		return ErrNotAttachmentOwner
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return record(ctx, uc.outbox, EventAttachmentDeleted, a.CardID, actor, map[string]any{"attachment": a})
+	})
+	if err != nil {
+		return err
+	}
+	uc.removeObject(ctx, a.StorageKey)
+	return nil
+}
+
+// removeObject deletes an object nothing refers to any more; a failure only leaves an orphan behind
+func (uc *AttachmentUsecase) removeObject(ctx context.Context, key string) {
+	if err := uc.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		logs.Ctx(ctx).Warnf("[attachment] remove orphaned object %s: %v", key, err)
+	}
+}
+
+// limitReader fails once more than max bytes have been read, so storage abandons the upload
+type limitReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n, errAttachmentTooLarge
+	}
+	return n, err
+}
+
+// cleanFilename keeps the base name of what the client sent, without control characters,
+// cut to maxFilenameLength characters with the extension kept
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+	if r := []rune(name); len(r) > maxFilenameLength {
+		ext := []rune(path.Ext(name))
+		if len(ext) > 16 {
+			ext = nil
+		}
+		name = string(r[:maxFilenameLength-len(ext)]) + string(ext)
+	}
+	return name
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+type fakeCards struct {
+	repositories.CardRepository // calls other than GetByID panic
+	card                        *entities.Card
+}
+
+func (f fakeCards) GetByID(context.Context, uuid.UUID) (*entities.Card, error) { return f.card, nil }
+
+type fakeAttachments struct {
+	repositories.AttachmentRepository
+	created []*entities.CardAttachment
+}
+
+func (f *fakeAttachments) Create(_ context.Context, a *entities.CardAttachment) error {
+	f.created = append(f.created, a)
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	added []*entities.OutboxEvent
+}
+
+func (f *fakeOutbox) Add(_ context.Context, e *entities.OutboxEvent) error {
+	f.added = append(f.added, e)
+	return nil
+}
+
+type noTx struct{}
+
+func (noTx) WithinTx(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }
+
+type attachmentTest struct {
+	uc     *AttachmentUsecase
+	dir    string
+	repo   *fakeAttachments
+	outbox *fakeOutbox
+	cardID uuid.UUID
+}
+
+func newAttachmentTest(t *testing.T, maxBytes int64) *attachmentTest {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := storage.New(storage.Config{Driver: "local", Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := &attachmentTest{dir: dir, repo: &fakeAttachments{}, outbox: &fakeOutbox{}, cardID: uuid.New()}
+	cfg := config.AttachmentConfig{MaxBytes: maxBytes, AllowedTypes: []string{"application/pdf", "text/plain", "image/png"}}
+	at.uc = NewAttachmentUsecase(at.repo, fakeCards{card: &entities.Card{ID: at.cardID, IsActive: true}}, noTx{}, at.outbox, store, cfg, "")
+	return at
+}
+
+// objects returns the files the upload left in storage
+func (at *attachmentTest) objects(t *testing.T) []string {
+	t.Helper()
+	var out []string
+	err := filepath.WalkDir(at.dir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			out = append(out, p)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+func TestUploadStoresWithChecksum(t *testing.T) {
+	at := newAttachmentTest(t, 1<<20)
+	a, err := at.uc.Upload(context.Background(), at.cardID, uuid.New(), `C:\Users\me\cv.pdf`, bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	sum := sha256.Sum256(pdfContent)
+	if a.Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("Checksum = %s, want %x", a.Checksum, sum)
+	}
+	if a.SizeBytes != int64(len(pdfContent)) || a.ContentType != "application/pdf" || a.Filename != "cv.pdf" {
+		t.Errorf("attachment = %+v", a)
+	}
+	if len(at.repo.created) != 1 || len(at.outbox.added) != 1 || at.outbox.added[0].EventType != EventAttachmentAdded {
+		t.Errorf("created %d rows and %d events, want 1 and 1 %s", len(at.repo.created), len(at.outbox.added), EventAttachmentAdded)
+	}
+	if objs := at.objects(t); len(objs) != 1 {
+		t.Errorf("stored %d objects, want 1", len(objs))
+	}
+}
+
+func TestUploadTooLargeStoresNothing(t *testing.T) {
+	for _, size := range []int{sniffLen - 100, sniffLen * 3} { // cut off inside and after the sniffed head
+		at := newAttachmentTest(t, int64(size-1))
+		content := append([]byte("hello\n"), bytes.Repeat([]byte("a"), size-6)...)
+		_, err := at.uc.Upload(context.Background(), at.cardID, uuid.New(), "a.txt", bytes.NewReader(content))
+		if !errors.Is(err, ErrAttachmentTooLarge) {
+			t.Errorf("size %d: Upload = %v, want ErrAttachmentTooLarge", size, err)
+		}
+		if objs := at.objects(t); len(objs) != 0 || len(at.repo.created) != 0 {
+			t.Errorf("size %d: left objects %v and %d rows", size, objs, len(at.repo.created))
+		}
+	}
+	// exactly at the limit is accepted
+	at := newAttachmentTest(t, int64(len(pdfContent)))
+	if _, err := at.uc.Upload(context.Background(), at.cardID, uuid.New(), "a.pdf", bytes.NewReader(pdfContent)); err != nil {
+		t.Errorf("Upload at the limit: %v", err)
+	}
+}
+
+func TestUploadRejectsType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    *errs.HttpError
+	}{
+		{"html named pdf", []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), ErrAttachmentType},
+		{"executable", append([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00"), make([]byte, 200)...), ErrAttachmentType},
+		{"empty", nil, ErrAttachmentEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := newAttachmentTest(t, 1<<20)
+			_, err := at.uc.Upload(context.Background(), at.cardID, uuid.New(), "cv.pdf", bytes.NewReader(tt.content))
+			var he *errs.HttpError
+			if !errors.As(err, &he) || he.Code != tt.want.Code {
+				t.Errorf("Upload = %v, want %s", err, tt.want.Code)
+			}
+			if objs := at.objects(t); len(objs) != 0 {
+				t.Errorf("left objects %v", objs)
+			}
+		})
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	long := strings.Repeat("ก", 300)
+	tests := []struct {
+		in, want string
+	}{
+		{"cv.pdf", "cv.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\me\cv.pdf`, "cv.pdf"},
+		{"a\x00b\nc.txt", "abc.txt"},
+		{"  spaced.txt  ", "spaced.txt"},
+		{"", "file"},
+		{"..", "file"},
+		{"/", "file"},
+		{"dir/", "dir"},
+		{long + ".pdf", strings.Repeat("ก", maxFilenameLength-4) + ".pdf"},
+		{long + "." + strings.Repeat("x", 20), strings.Repeat("ก", maxFilenameLength)},
+	}
+	for _, tt := range tests {
+		if got := cleanFilename(tt.in); got != tt.want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package usecases
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"time"
 
 	"interview-tracker/internal/adapters/repositories"
 	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/logs"
 	"interview-tracker/internal/pkg/token"
 
 	"github.com/google/uuid"
@@ -16,7 +20,8 @@ import (
 
 // ===== unchanged interfaces/types =====
 type AuthUsecase interface {
-	Login(ctx context.Context, email, password string) (accessToken, refreshToken, refID string, err error)
+	// Login checks the password; email is locked out from clientIP (middleware.ClientIP) after repeated failures
+	Login(ctx context.Context, email, password, clientIP string) (accessToken, refreshToken, refID string, err error)
 	Refresh(ctx context.Context, refreshToken string) (newAccess, newRefresh, refID string, err error)
 	Logout(ctx context.Context, refID string) error
 }
@@ -39,61 +44,46 @@ type sessionData struct {
 
 // ===== main flows =====
 
-func (s *authUsecase) Login(ctx context.Context, email, password string) (string, string, string, error) {
-	u, err := s.userRepo.GetByEmail(email)
-	if err != nil {
-		return "", "", "", err
-	}
-	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
-		return "", "", "", errors.New("invalid credentials")
-	}
-
-	perms, err := s.userRepo.GetPermissionsByUserID(u.ID)
-	if err != nil {
-		return "", "", "", err
-	}
-
-	refID := uuid.NewString()
-	accessTTL := readMinutes(config.EnvConfig.AccessTTLMinutes)
-	refreshTTLDays := config.EnvConfig.RefreshTTLDays
-	sessionData := sessionData{UserID: u.ID, Email: u.Email, Role: u.Role.Code, Perms: perms}
-
-	if err := s.rdb.Set(ctx, "session:"+refID, token.MustJSON(sessionData), accessTTL).Err(); err != nil {
-		return "", "", "", err
-	}
-
-	access, err := token.SignAccess(refID, accessTTL)
-	if err != nil {
-		_ = s.rdb.Del(ctx, "session:"+refID).Err()
+func (s *authUsecase) Login(ctx context.Context, email, password, clientIP string) (string, string, string, error) {
+	lockKey := loginFailKey(clientIP, email)
+	if err := s.checkLockout(ctx, lockKey); err != nil {
 		return "", "", "", err
 	}
-
-	rt, err := token.NewRefreshToken()
+	u, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		_ = s.rdb.Del(ctx, "session:"+refID).Err()
+		err = dbErr(err, ErrInvalidCredentials)
+		if errors.Is(err, ErrInvalidCredentials) {
+			s.recordFailure(ctx, lockKey)
+		}
 		return "", "", "", err
 	}
-	if err := s.saveRefresh(ctx, refID, rt, refreshTTLDays); err != nil {
-		_ = s.rdb.Del(ctx, "session:"+refID).Err()
-		return "", "", "", err
+	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+		s.recordFailure(ctx, lockKey)
+		return "", "", "", ErrInvalidCredentials
 	}
 
-	return access, rt, refID, nil
+	return s.issueSession(ctx, u)
 }
 
 func (s *authUsecase) Refresh(ctx context.Context, refreshToken string) (string, string, string, error) {
 	hash := token.Sha256Hex(refreshToken)
 	refID, err := s.rdb.Get(ctx, "rt:"+hash).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", "", "", ErrInvalidRefreshToken
+	}
 	if err != nil {
-		return "", "", "", errors.New("invalid or expired refresh token")
+		return "", "", "", err
 	}
 
 	_ = s.rdb.Del(ctx, "rt:"+hash).Err()
 
-	accessTTL := readMinutes(config.EnvConfig.AccessTTLMinutes)
+	accessTTL := config.EnvConfig.AccessTTL
 	val, err := s.rdb.Get(ctx, "session:"+refID).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", "", "", ErrInvalidRefreshToken
+	}
 	if err != nil {
-		return "", "", "", errors.New("session not found; please login again")
+		return "", "", "", err
 	}
 	_ = s.rdb.Expire(ctx, "session:"+refID, accessTTL).Err()
 
@@ -102,12 +92,12 @@ func (s *authUsecase) Refresh(ctx context.Context, refreshToken string) (string,
 		return "", "", "", err
 	}
 
-	refreshTTLDays := config.EnvConfig.RefreshTTLDays
+	refreshTTL := config.EnvConfig.RefreshTTL
 	newRT, err := token.NewRefreshToken()
 	if err != nil {
 		return "", "", "", err
 	}
-	if err := s.saveRefresh(ctx, refID, newRT, refreshTTLDays); err != nil {
+	if err := s.saveRefresh(ctx, refID, newRT, refreshTTL); err != nil {
 		return "", "", "", err
 	}
 
@@ -129,11 +119,112 @@ func (s *authUsecase) Logout(ctx context.Context, refID string) error {
 	return iter.Err()
 }
 
-func (s *authUsecase) saveRefresh(ctx context.Context, refID, raw string, ttlDays int) error {
+// issueSession stores the redis session for u and signs a new access/refresh pair.
+// Shared by password login and SSO so both end in the same session shape.
+func (s *authUsecase) issueSession(ctx context.Context, u *entities.User) (string, string, string, error) {
+	perms, err := s.userRepo.GetPermissionsByUserID(ctx, u.ID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	refID := uuid.NewString()
+	accessTTL := config.EnvConfig.AccessTTL
+	refreshTTL := config.EnvConfig.RefreshTTL
+	roleCode := ""
+	if u.Role != nil {
+		roleCode = u.Role.Code
+	}
+	sessionData := sessionData{UserID: u.ID, Email: u.Email, Role: roleCode, Perms: perms}
+
+	if err := s.rdb.Set(ctx, "session:"+refID, token.MustJSON(sessionData), accessTTL).Err(); err != nil {
+		return "", "", "", err
+	}
+
+	access, err := token.SignAccess(refID, accessTTL)
+	if err != nil {
+		_ = s.rdb.Del(ctx, "session:"+refID).Err()
+		return "", "", "", err
+	}
+
+	rt, err := token.NewRefreshToken()
+	if err != nil {
+		_ = s.rdb.Del(ctx, "session:"+refID).Err()
+		return "", "", "", err
+	}
+	if err := s.saveRefresh(ctx, refID, rt, refreshTTL); err != nil {
+		_ = s.rdb.Del(ctx, "session:"+refID).Err()
+		return "", "", "", err
+	}
+
+	return access, rt, refID, nil
+}
+
+func (s *authUsecase) saveRefresh(ctx context.Context, refID, raw string, ttl time.Duration) error {
 	hash := token.Sha256Hex(raw)
-	return s.rdb.Set(ctx, "rt:"+hash, refID, days(ttlDays)).Err()
+	return s.rdb.Set(ctx, "rt:"+hash, refID, ttl).Err()
+}
+
+// ===== login lockout =====
+
+// failed logins are counted per client IP and email under this prefix; see config.LoginLockoutConfig
+const loginFailPrefix = "login_fail:"
+
+// loginFailKey keys the counter by client IP and a hash of the normalised email, so one
+// shared IP (NAT, an untrusted ingress) cannot lock every account at once
+func loginFailKey(clientIP, email string) string {
+	return loginFailPrefix + clientIP + ":" + token.Sha256Hex(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// loginFailScript counts a failure: the counter lives for the window from the first failure,
+// and the failure that reaches the limit extends it to the lockout duration.
+// ARGV: window ms, lockout ms, max failures
+var loginFailScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+if n == tonumber(ARGV[3]) then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return n
+`)
+
+// checkLockout returns ErrLoginLocked while key (loginFailKey) has used up its failed logins.
+func (s *authUsecase) checkLockout(ctx context.Context, key string) error {
+	cfg := config.EnvConfig.LoginLockout
+	if cfg.MaxFailures <= 0 {
+		return nil
+	}
+	n, err := s.rdb.Get(ctx, key).Int()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		// without redis the login rate limit still applies; do not lock everyone out
+		logs.Ctx(ctx).Warnf("[auth] login lockout unavailable: %v", err)
+		return nil
+	}
+	if n < cfg.MaxFailures {
+		return nil
+	}
+	ttl, err := s.rdb.PTTL(ctx, key).Result()
+	if err != nil || ttl <= 0 {
+		ttl = cfg.Duration
+	}
+	logs.Ctx(ctx).Warnf("[auth] login locked for %s (%d failures)", key, n)
+	return ErrLoginLocked(int(math.Ceil(ttl.Seconds())))
+}
+
+func (s *authUsecase) recordFailure(ctx context.Context, key string) {
+	cfg := config.EnvConfig.LoginLockout
+	if cfg.MaxFailures <= 0 {
+		return
+	}
+	args := []any{cfg.Window.Milliseconds(), cfg.Duration.Milliseconds(), cfg.MaxFailures}
+	if err := loginFailScript.Run(ctx, s.rdb, []string{key}, args...).Err(); err != nil {
+		logs.Ctx(ctx).Warnf("[auth] count failed login: %v", err)
+	}
 }
 
 // ===== helpers =====
-func readMinutes(numMin int) time.Duration { return time.Duration(numMin) * time.Minute }
-func days(n int) time.Duration             { return time.Hour * 24 * time.Duration(n) }
+func days(n int) time.Duration { return time.Hour * 24 * time.Duration(n) }
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/models/calendar_models"
+	"interview-tracker/internal/pkg/ical"
+	"interview-tracker/internal/pkg/token"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeedPrefix marks a calendar feed token
+const CalendarFeedPrefix = "itc_"
+
+// a feed lists interviews from a week ago (so they do not vanish as soon as they end)
+// up to a year ahead
+const (
+	feedLookback  = 7 * 24 * time.Hour
+	feedHorizon   = 365 * 24 * time.Hour
+	feedMaxEvents = 500
+)
+
+type CalendarUsecase struct {
+	cards repositories.CardRepository
+	feeds repositories.CalendarFeedRepository
+	users repositories.UserRepository
+	// feedURL is the public feed endpoint; the token is appended as ?token=
+	feedURL string
+}
+
+func NewCalendarUsecase(cards repositories.CardRepository, feeds repositories.CalendarFeedRepository, users repositories.UserRepository, feedURL string) *CalendarUsecase {
+	return &CalendarUsecase{cards: cards, feeds: feeds, users: users, feedURL: feedURL}
+}
+
+// CardICS renders one card as an iCalendar file
+func (uc *CalendarUsecase) CardICS(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	card, err := uc.cards.GetByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrCardNotFound)
+	}
+	return ical.Marshal(card.Title, []ical.Event{icalEvent(card, time.Now())}), nil
+}
+
+// IssueFeed creates a feed token for user. The raw token (inside the URL) is returned once; only its sha256 is stored.
+func (uc *CalendarUsecase) IssueFeed(ctx context.Context, user uuid.UUID, req calendar_models.CreateFeedTokenReq) (*calendar_models.CreateFeedTokenResp, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	prefix := CalendarFeedPrefix + hex.EncodeToString(b)
+	secret, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	raw := prefix + "_" + secret
+
+	var txnDtm = time.Now()
+	t := &entities.CalendarFeedToken{
+		UserID:    user,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		TokenHash: token.Sha256Hex(raw),
+		IsActive:  true,
+		CreatedAt: txnDtm,
+		UpdatedAt: txnDtm,
+	}
+	if err := uc.feeds.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return &calendar_models.CreateFeedTokenResp{
+		ID:     t.ID,
+		Name:   t.Name,
+		Prefix: t.Prefix,
+		Token:  raw,
+		URL:    uc.feedURL + "?token=" + url.QueryEscape(raw),
+	}, nil
+}
+
+func (uc *CalendarUsecase) ListFeeds(ctx context.Context, user uuid.UUID) ([]*entities.CalendarFeedToken, error) {
+	return uc.feeds.ListByUser(ctx, user)
+}
+
+// RevokeFeed revokes one of user's own feed tokens; other users' tokens are reported as not found
+func (uc *CalendarUsecase) RevokeFeed(ctx context.Context, user, id uuid.UUID) error {
+	t, err := uc.feeds.GetByID(ctx, id)
+	if err != nil {
+		return dbErr(err, ErrCalendarFeedNotFound)
+	}
+	if t.UserID != user {
+		return ErrCalendarFeedNotFound
+	}
+	return uc.feeds.Revoke(ctx, id)
+}
+
+// Feed renders the interviews the token's owner is assigned to, as long as the owner is
+// still an active user holding card_view. Archived cards are kept in the feed as
+// CANCELLED so subscribed calendars remove them.
+func (uc *CalendarUsecase) Feed(ctx context.Context, raw string) ([]byte, error) {
+	if !strings.HasPrefix(raw, CalendarFeedPrefix) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	t, err := uc.feeds.GetByHash(ctx, token.Sha256Hex(raw))
+	if err != nil {
+		return nil, dbErr(err, ErrCalendarFeedNotFound)
+	}
+	if t.RevokedAt != nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+	// permissions are empty for a deactivated user, so this also ends a deactivated owner's feed
+	perms, err := uc.users.GetPermissionsByUserID(ctx, t.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(perms, "card_view") {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	now := time.Now()
+	cards, err := uc.cards.Calendar(ctx, repositories.CalendarFilter{
+		From:            now.Add(-feedLookback),
+		To:              now.Add(feedHorizon),
+		UserID:          &t.UserID,
+		IncludeArchived: true,
+		Limit:           feedMaxEvents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = uc.feeds.TouchLastUsed(ctx, t.ID, now)
+
+	events := make([]ical.Event, 0, len(cards))
+	for _, c := range cards {
+		events = append(events, icalEvent(c, now))
+	}
+	return ical.Marshal("Interview Tracker", events), nil
+}
+
+func icalEvent(c *entities.Card, now time.Time) ical.Event {
+	description := c.Description
+	if c.Position != "" {
+		description = strings.TrimSpace("Position: " + c.Position + "\n\n" + c.Description)
+	}
+	location := c.Location
+	if location == "" {
+		location = c.MeetingURL
+	}
+	return ical.Event{
+		UID:          c.ID.String() + "@interview-tracker",
+		Sequence:     c.Sequence,
+		Start:        c.ScheduledAt,
+		End:          c.EndsAt,
+		Stamp:        now,
+		LastModified: c.UpdatedAt,
+		Summary:      c.Title,
+		Description:  description,
+		Location:     location,
+		URL:          c.MeetingURL,
+		Cancelled:    !c.IsActive,
+	}
+}
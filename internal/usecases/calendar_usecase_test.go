@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestICalEvent(t *testing.T) {
+	now := time.Now()
+	card := &entities.Card{
+		ID:          uuid.New(),
+		Title:       "Interview",
+		Position:    "Backend",
+		Description: "notes",
+		ScheduledAt: now,
+		EndsAt:      now.Add(time.Hour),
+		MeetingURL:  "https://meet.example.com/x",
+		Sequence:    4,
+		IsActive:    true,
+	}
+	e := icalEvent(card, now)
+	if e.Cancelled || e.Sequence != 4 || e.UID != card.ID.String()+"@interview-tracker" {
+		t.Errorf("active card: %+v", e)
+	}
+	if e.Description != "Position: Backend\n\nnotes" || e.Location != card.MeetingURL {
+		t.Errorf("description %q, location %q", e.Description, e.Location)
+	}
+
+	card.IsActive = false
+	card.Sequence = 5
+	if e := icalEvent(card, now); !e.Cancelled || e.Sequence != 5 {
+		t.Errorf("archived card: cancelled %v, sequence %d", e.Cancelled, e.Sequence)
+	}
+}
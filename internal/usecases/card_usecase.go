@@ -1,138 +1,688 @@
 package usecases
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"interview-tracker/internal/adapters/repositories"
 	"interview-tracker/internal/entities"
-	"interview-tracker/internal/pkg/errs"
+	"interview-tracker/internal/models/card_models"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/notify"
+	"interview-tracker/internal/pkg/rank"
 
 	"github.com/google/uuid"
 )
 
+// CardUsecase writes every change together with its history and outbox event in one transaction
 type CardUsecase struct {
-	repo repositories.CardRepository
+	repo     repositories.CardRepository
+	tx       repositories.Transactor
+	outbox   repositories.OutboxRepository
+	notifier notify.Notifier // @mentions in comments
 }
 
-func NewCardUsecase(r repositories.CardRepository) *CardUsecase { return &CardUsecase{repo: r} }
+func NewCardUsecase(r repositories.CardRepository, tx repositories.Transactor, outbox repositories.OutboxRepository, n notify.Notifier) *CardUsecase {
+	return &CardUsecase{repo: r, tx: tx, outbox: outbox, notifier: n}
+}
+
+// calendar queries are bounded so one request cannot scan the whole table
+const (
+	maxCalendarRange  = 93 * 24 * time.Hour
+	maxCalendarEvents = 500
+)
 
-func (uc *CardUsecase) Create(card *entities.Card) error {
+func (uc *CardUsecase) Create(ctx context.Context, card *entities.Card, interviewerIDs []uuid.UUID) error {
 	var txnDtm = time.Now()
+	card.Interviewers = interviewers(interviewerIDs)
+	if card.EndsAt.IsZero() {
+		card.EndsAt = card.ScheduledAt.Add(entities.DefaultDuration)
+	}
 	card.StatusCode = "todo"
 	card.CreatedAt = txnDtm
 	card.UpdatedAt = txnDtm
-	if err := uc.repo.Create(card); err != nil {
-		return err
-	}
-	if err := uc.addHistory(card.CreatedBy, card.ID, card.StatusCode, card.Description); err != nil {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// new cards go to the bottom of the todo column
+		if err := uc.place(ctx, card, card.StatusCode, nil, false); err != nil {
+			return err
+		}
+		if err := uc.schedule(ctx, card); err != nil {
+			return err
+		}
+		if err := uc.addHistory(ctx, card.CreatedBy, card.ID, entities.HistoryCardCreated, card.StatusCode, card.Description); err != nil {
+			return err
+		}
+		card.Render()
+		return record(ctx, uc.outbox, EventCardCreated, card.ID, card.CreatedBy, map[string]any{"card": card})
+	})
+	if err != nil {
 		return err
 	}
+	metrics.CardsCreated.WithLabelValues(card.StatusCode).Inc()
 	return nil
 }
 
-func (uc *CardUsecase) UpdatePartial(id uuid.UUID, patch map[string]any) (*entities.Card, error) {
-	card, err := uc.repo.GetByID(id)
+// UpdatePartial applies patch to the card. The card is read under a row lock inside the
+// transaction, so a concurrent move or archive is never written back stale.
+func (uc *CardUsecase) UpdatePartial(ctx context.Context, id uuid.UUID, patch map[string]any, actor uuid.UUID) (*entities.Card, error) {
+	var card *entities.Card
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if card, err = uc.repo.GetForUpdate(ctx, id); err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		if !card.IsActive {
+			return ErrCardArchived
+		}
+		reschedule := applyPatch(card, patch)
+		card.UpdatedAt = time.Now()
+		card.UpdatedBy = actor
+		card.Render()
+		if reschedule {
+			if err := uc.schedule(ctx, card); err != nil {
+				return err
+			}
+		} else if err := uc.repo.Update(ctx, card); err != nil {
+			return err
+		}
+		if err := uc.addHistory(ctx, actor, card.ID, entities.HistoryCardUpdated, card.StatusCode, card.Description); err != nil {
+			return err
+		}
+		return record(ctx, uc.outbox, EventCardUpdated, card.ID, actor, map[string]any{"card": card})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return card, nil
+}
+
+// applyPatch copies the patched fields onto card and reports whether the time slot or the
+// interviewers changed, which must be checked for double-booking
+func applyPatch(card *entities.Card, patch map[string]any) bool {
 	if v, ok := patch["title"].(string); ok {
 		card.Title = v
 	}
 	if v, ok := patch["description"].(string); ok {
 		card.Description = v
 	}
+	if v, ok := patch["position"].(string); ok {
+		card.Position = v
+	}
+	// a changed time or place bumps the iCalendar SEQUENCE so subscribed calendars update the event
+	before := *card
+	if v, ok := patch["time_zone"].(string); ok {
+		card.TimeZone = v
+	}
+	if v, ok := patch["location"].(string); ok {
+		card.Location = v
+	}
+	if v, ok := patch["meeting_url"].(string); ok {
+		card.MeetingURL = v
+	}
+	reschedule := false
 	if v, ok := patch["scheduled_at"].(time.Time); ok {
+		if _, ok := patch["ends_at"]; !ok {
+			// moving only the start keeps the interview's length
+			card.EndsAt = card.EndsAt.Add(v.Sub(card.ScheduledAt))
+		}
 		card.ScheduledAt = v
+		reschedule = true
 	}
-	card.UpdatedAt = time.Now()
-	if err := uc.repo.Update(card); err != nil {
-		return nil, err
+	if v, ok := patch["ends_at"].(time.Time); ok {
+		card.EndsAt = v
+		reschedule = true
+	}
+	if !card.ScheduledAt.Equal(before.ScheduledAt) || !card.EndsAt.Equal(before.EndsAt) ||
+		card.Location != before.Location || card.MeetingURL != before.MeetingURL {
+		card.Sequence++
 	}
+	if v, ok := patch["interviewer_ids"].([]uuid.UUID); ok {
+		card.Interviewers = interviewers(v)
+		reschedule = true
+	}
+	return reschedule
+}
 
-	if err := uc.addHistory(card.CreatedBy, card.ID, card.StatusCode, card.Description); err != nil {
+func (uc *CardUsecase) UpdateStatus(ctx context.Context, id uuid.UUID, status string, actor uuid.UUID) (*entities.Card, error) {
+	if status != "todo" && status != "in_progress" && status != "done" {
+		return nil, ErrInvalidStatus
+	}
+	var card *entities.Card
+	var from string
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the column lock comes before the row lock, in the same order as place and Rebalance
+		if err := uc.repo.LockColumn(ctx, status); err != nil {
+			return err
+		}
+		var err error
+		if card, err = uc.repo.GetForUpdate(ctx, id); err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		if !card.IsActive {
+			return ErrCardArchived
+		}
+		from = card.StatusCode
+		card.StatusCode = status
+		card.UpdatedAt = time.Now()
+		card.UpdatedBy = actor
+		card.Render()
+		// a card changing column goes to the bottom of its new column
+		if from != status {
+			if err := uc.place(ctx, card, status, nil, false); err != nil {
+				return err
+			}
+		}
+		if err := uc.repo.Update(ctx, card); err != nil {
+			return err
+		}
+		// add history
+		action := entities.HistoryStatusChanged
+		if from == status {
+			action = entities.HistoryCardUpdated
+		}
+		if err := uc.addHistory(ctx, actor, id, action, card.StatusCode, card.Description); err != nil {
+			return err
+		}
+		if from == status {
+			return nil
+		}
+		return record(ctx, uc.outbox, EventCardStatusChanged, id, actor, map[string]any{"card": card, "from": from, "to": status})
+	})
+	if err != nil {
 		return nil, err
 	}
+	if from != status {
+		metrics.CardStatusTransitions.WithLabelValues(from, status).Inc()
+	}
 	return card, nil
 }
 
-func (uc *CardUsecase) UpdateStatus(id uuid.UUID, status string, actor uuid.UUID) (*entities.Card, error) {
-	if status != "todo" && status != "in_progress" && status != "done" {
-		return nil, errors.New("invalid status")
+func (uc *CardUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entities.Card, error) {
+	card, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrCardNotFound)
 	}
-	card, err := uc.repo.GetByID(id)
+	card.Render()
+	return card, nil
+}
+
+func (uc *CardUsecase) List(ctx context.Context, status, sort string, page, size int) ([]*entities.Card, int64, error) {
+	list, total, err := uc.repo.List(ctx, status, sort, page, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, c := range list {
+		c.Render()
+	}
+	return list, total, nil
+}
+
+// Board returns every status column, with names in lang, its total and its first limit cards by rank
+func (uc *CardUsecase) Board(ctx context.Context, lang string, limit int) ([]card_models.BoardColumn, error) {
+	statuses, err := uc.ListStatuses(ctx, lang)
 	if err != nil {
 		return nil, err
 	}
-	card.StatusCode = status
-	card.UpdatedAt = time.Now()
-	if err := uc.repo.Update(card); err != nil {
+	totals, err := uc.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := uc.repo.Board(ctx, limit)
+	if err != nil {
 		return nil, err
 	}
+	byStatus := map[string][]*entities.Card{}
+	for _, c := range cards {
+		c.Render()
+		byStatus[c.StatusCode] = append(byStatus[c.StatusCode], c)
+	}
+	columns := make([]card_models.BoardColumn, 0, len(statuses))
+	for _, s := range statuses {
+		list := byStatus[s.StatusCode]
+		if list == nil {
+			list = []*entities.Card{}
+		}
+		columns = append(columns, card_models.BoardColumn{Status: s, Total: totals[s.StatusCode], Cards: list})
+	}
+	return columns, nil
+}
 
-	// add history
-	if err := uc.addHistory(actor, id, card.StatusCode, card.Description); err != nil {
+// Move changes the card's status and its position in the column in one step: right after
+// req.AfterID, right before req.BeforeID, or at the bottom of the column with neither.
+func (uc *CardUsecase) Move(ctx context.Context, id uuid.UUID, req card_models.MoveCardReq, actor uuid.UUID) (*entities.Card, error) {
+	if (req.AfterID != "" && req.BeforeID != "") || req.AfterID == id.String() || req.BeforeID == id.String() {
+		return nil, ErrMoveAnchors
+	}
+	var anchor *uuid.UUID
+	after := req.AfterID != ""
+	if after {
+		a := uuid.MustParse(req.AfterID) // validated by the binding tag
+		anchor = &a
+	} else if req.BeforeID != "" {
+		a := uuid.MustParse(req.BeforeID)
+		anchor = &a
+	}
+	var card *entities.Card
+	var from string
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the column lock comes before the row lock, in the same order as place and Rebalance
+		if err := uc.repo.LockColumn(ctx, req.Status); err != nil {
+			return err
+		}
+		var err error
+		if card, err = uc.repo.GetForUpdate(ctx, id); err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		if !card.IsActive {
+			return ErrCardArchived
+		}
+		from = card.StatusCode
+		card.StatusCode = req.Status
+		card.UpdatedAt = time.Now()
+		card.UpdatedBy = actor
+		card.Render()
+		if err := uc.place(ctx, card, req.Status, anchor, after); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, card); err != nil {
+			return err
+		}
+		if from == req.Status {
+			return record(ctx, uc.outbox, EventCardMoved, id, actor, map[string]any{"card": card})
+		}
+		if err := uc.addHistory(ctx, actor, id, entities.HistoryStatusChanged, card.StatusCode, card.Description); err != nil {
+			return err
+		}
+		return record(ctx, uc.outbox, EventCardStatusChanged, id, actor, map[string]any{"card": card, "from": from, "to": req.Status})
+	})
+	if err != nil {
 		return nil, err
 	}
+	if from != req.Status {
+		metrics.CardStatusTransitions.WithLabelValues(from, req.Status).Inc()
+	}
 	return card, nil
 }
 
-func (uc *CardUsecase) GetByID(id uuid.UUID) (*entities.Card, error) { return uc.repo.GetByID(id) }
+// place sets card.Rank for a spot in the status column: next to anchor (after or before it), or
+// at the bottom when anchor is nil. It locks the column for the rest of the transaction, and
+// renumbers the column once when the keys around the spot are too long or out of order.
+func (uc *CardUsecase) place(ctx context.Context, card *entities.Card, status string, anchor *uuid.UUID, after bool) error {
+	if err := uc.repo.LockColumn(ctx, status); err != nil {
+		return err
+	}
+	r, err := uc.rankAt(ctx, card.ID, status, anchor, after)
+	if err != nil && !errors.Is(err, rank.ErrOrder) {
+		return err
+	}
+	if err != nil || len(r) > rank.MaxLen {
+		if err := uc.repo.Rebalance(ctx, status); err != nil {
+			return err
+		}
+		if r, err = uc.rankAt(ctx, card.ID, status, anchor, after); err != nil {
+			return err
+		}
+	}
+	card.Rank = r
+	return nil
+}
 
-func (uc *CardUsecase) List(status string, page, size int) ([]*entities.Card, int64, error) {
-	return uc.repo.List(status, page, size)
+// rankAt makes a rank for the spot described by place, ignoring the card being moved (id)
+func (uc *CardUsecase) rankAt(ctx context.Context, id uuid.UUID, status string, anchor *uuid.UUID, after bool) (string, error) {
+	if anchor == nil {
+		last, err := uc.repo.LastRank(ctx, status, id)
+		if err != nil {
+			return "", err
+		}
+		return rank.After(last)
+	}
+	at, err := uc.repo.Rank(ctx, status, *anchor)
+	if err != nil {
+		return "", dbErr(err, ErrMoveAnchorMissing)
+	}
+	next, err := uc.repo.AdjacentRank(ctx, status, at, *anchor, id, after)
+	if err != nil {
+		return "", err
+	}
+	if after {
+		return rank.Between(at, next)
+	}
+	return rank.Between(next, at)
 }
 
-func (uc *CardUsecase) AddComment(authorID uuid.UUID, cardID, content string) error {
+// schedule validates the time slot and saves the card unless an interviewer is double-booked,
+// in which case ErrScheduleConflict lists the overlapping cards.
+func (uc *CardUsecase) schedule(ctx context.Context, card *entities.Card) error {
+	if card.TimeZone == "" {
+		card.TimeZone = entities.DefaultTimeZone
+	}
+	if !card.EndsAt.After(card.ScheduledAt) {
+		return ErrInvalidTimeRange
+	}
+	conflicts, err := uc.repo.Schedule(ctx, card)
+	if err != nil {
+		// the only foreign key the request controls is the interviewer
+		return dbErr(err, ErrUserNotFound)
+	}
+	if len(conflicts) > 0 {
+		events := make([]card_models.CalendarEvent, 0, len(conflicts))
+		for _, c := range conflicts {
+			events = append(events, calendarEvent(c, nil))
+		}
+		return ErrScheduleConflict.WithConflicts(events)
+	}
+	return nil
+}
+
+// Calendar returns the interviews overlapping [q.From, q.To) for q.UserID or q.Position,
+// or for caller when neither is given.
+func (uc *CardUsecase) Calendar(ctx context.Context, q card_models.CalendarQuery, caller uuid.UUID) ([]card_models.CalendarEvent, error) {
+	if !q.To.After(q.From) {
+		return nil, ErrInvalidTimeRange
+	}
+	if q.To.Sub(q.From) > maxCalendarRange {
+		return nil, ErrCalendarRangeTooLong
+	}
+	f := repositories.CalendarFilter{From: q.From, To: q.To, Position: q.Position, Limit: maxCalendarEvents}
+	if q.UserID != "" {
+		id := uuid.MustParse(q.UserID) // validated by the binding tag
+		f.UserID = &id
+	} else if q.Position == "" {
+		f.UserID = &caller
+	}
+	var loc *time.Location
+	if q.TimeZone != "" {
+		loc, _ = time.LoadLocation(q.TimeZone) // validated by the binding tag
+	}
+
+	cards, err := uc.repo.Calendar(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]card_models.CalendarEvent, 0, len(cards))
+	for _, c := range cards {
+		events = append(events, calendarEvent(c, loc))
+	}
+	return events, nil
+}
+
+// calendarEvent renders the card's times in loc, or in the card's own time zone when loc is nil
+func calendarEvent(c *entities.Card, loc *time.Location) card_models.CalendarEvent {
+	if loc == nil {
+		var err error
+		if loc, err = time.LoadLocation(c.TimeZone); err != nil {
+			loc = time.UTC
+		}
+	}
+	return card_models.CalendarEvent{
+		CardID:         c.ID,
+		Title:          c.Title,
+		Position:       c.Position,
+		StatusCode:     c.StatusCode,
+		StartsAt:       c.ScheduledAt.In(loc),
+		EndsAt:         c.EndsAt.In(loc),
+		TimeZone:       loc.String(),
+		Location:       c.Location,
+		MeetingURL:     c.MeetingURL,
+		InterviewerIDs: c.InterviewerIDs(),
+	}
+}
+
+func interviewers(ids []uuid.UUID) []entities.CardInterviewer {
+	out := make([]entities.CardInterviewer, 0, len(ids))
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, entities.CardInterviewer{UserID: id})
+		}
+	}
+	return out
+}
+
+// AddComment saves the comment, or a reply when req.ParentID is set, and notifies the users it mentions
+func (uc *CardUsecase) AddComment(ctx context.Context, authorID, cardID uuid.UUID, req card_models.AddCommentReq) (*entities.CardComment, error) {
 	cmt := &entities.CardComment{
-		CardID:    cardID,
+		CardID:    cardID.String(),
 		AuthorID:  authorID,
-		Content:   content,
+		Content:   req.Content,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		CreatedBy: authorID,
 		UpdatedBy: authorID,
+		Reactions: []entities.ReactionSummary{},
+	}
+	if req.ParentID != "" {
+		parent, err := uc.activeComment(ctx, uuid.MustParse(req.ParentID)) // validated by the binding tag
+		if err != nil {
+			return nil, err
+		}
+		if commentCardID(parent) != cardID {
+			return nil, ErrCommentNotFound
+		}
+		// threads are one level deep: a reply to a reply joins the same thread
+		cmt.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			cmt.ParentID = parent.ParentID
+		}
+	}
+	cmt.Render()
+	mentions, err := uc.resolveMentions(ctx, req.Content)
+	if err != nil {
+		return nil, err
 	}
-	return uc.repo.AddComment(cmt)
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.AddComment(ctx, cmt); err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		if err := uc.repo.SetMentions(ctx, cmt.ID, mentions); err != nil {
+			return err
+		}
+		cmt.Mentions = mentions
+		return record(ctx, uc.outbox, EventCommentCreated, cardID, authorID, map[string]any{"comment": cmt})
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyMentions(ctx, cmt, authorID, mentions)
+	return cmt, nil
 }
 
-func (uc *CardUsecase) UpdateComment(authorID, commentId uuid.UUID, content string) error {
-	comment, err := uc.repo.GetCommentByID(commentId)
+// UpdateComment changes the content, keeping the previous version in the edit history,
+// and notifies users mentioned for the first time
+func (uc *CardUsecase) UpdateComment(ctx context.Context, authorID, commentId uuid.UUID, content string) error {
+	comment, err := uc.activeComment(ctx, commentId)
 	if err != nil {
 		return err
 	}
 	if comment.AuthorID != authorID {
-		return errs.Unauthorized("unauthorized")
+		return ErrNotCommentAuthor
+	}
+	mentions, err := uc.resolveMentions(ctx, content)
+	if err != nil {
+		return err
+	}
+	added := newMentions(comment.Mentions, mentions)
+	now := time.Now()
+	var edit *entities.CommentEdit
+	if content != comment.Content {
+		edit = &entities.CommentEdit{CommentID: comment.ID, Content: comment.Content, EditedBy: authorID, EditedAt: now}
+		comment.Edited = true
+		comment.EditedAt = &now
 	}
 	cmt := &entities.CardComment{
+		ID:        comment.ID,
 		CardID:    comment.CardID,
 		AuthorID:  authorID,
 		Content:   content,
-		UpdatedAt: time.Now(),
+		Edited:    comment.Edited,
+		EditedAt:  comment.EditedAt,
+		UpdatedAt: now,
 		UpdatedBy: authorID,
 	}
-	return uc.repo.UpdateComment(cmt)
+	comment.Content, comment.UpdatedAt, comment.UpdatedBy = cmt.Content, cmt.UpdatedAt, cmt.UpdatedBy
+	comment.Render()
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if edit != nil {
+			if err := uc.repo.AddCommentEdit(ctx, edit); err != nil {
+				return err
+			}
+		}
+		if err := uc.repo.UpdateComment(ctx, cmt); err != nil {
+			return err
+		}
+		if err := uc.repo.SetMentions(ctx, comment.ID, mentions); err != nil {
+			return err
+		}
+		comment.Mentions = mentions
+		return record(ctx, uc.outbox, EventCommentUpdated, commentCardID(comment), authorID, map[string]any{"comment": comment})
+	})
+	if err != nil {
+		return err
+	}
+	uc.notifyMentions(ctx, comment, authorID, added)
+	return nil
 }
 
-func (uc *CardUsecase) ListComments(cardID string, page, size int) ([]*entities.CardComment, int64, error) {
-	return uc.repo.ListComments(cardID, page, size)
+// ListComments returns the card's comments in the flat or threaded view (see card_models.ListCommentsQuery),
+// with reactions as seen by caller. The total counts top-level comments in the threaded view.
+// Deleted comments are left out, except as a placeholder heading a thread that still has replies;
+// q.IncludeDeleted (moderators only) lists them in full.
+func (uc *CardUsecase) ListComments(ctx context.Context, cardID, caller uuid.UUID, q card_models.ListCommentsQuery) ([]*entities.CardComment, int64, error) {
+	threaded := q.View == "threaded"
+	list, total, err := uc.repo.ListComments(ctx, cardID, threaded, q.IncludeDeleted, q.Page, q.PageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	all := list
+	if threaded {
+		ids := make([]uuid.UUID, 0, len(list))
+		for _, c := range list {
+			ids = append(ids, c.ID)
+		}
+		replies, err := uc.repo.ListReplies(ctx, ids, q.IncludeDeleted)
+		if err != nil {
+			return nil, 0, err
+		}
+		byParent := map[uuid.UUID][]*entities.CardComment{}
+		for _, r := range replies {
+			byParent[*r.ParentID] = append(byParent[*r.ParentID], r)
+		}
+		for _, c := range list {
+			c.Replies = byParent[c.ID]
+		}
+		all = append(all, replies...)
+	}
+	for _, c := range all {
+		c.Render()
+	}
+	if err := uc.attachReactions(ctx, all, caller); err != nil {
+		return nil, 0, err
+	}
+	if !q.IncludeDeleted {
+		for _, c := range list {
+			if !c.IsActive {
+				c.Redact()
+			}
+		}
+	}
+	return list, total, nil
 }
 
-func (uc *CardUsecase) DeleteComment(authorID, commentId uuid.UUID) error {
-	comment, err := uc.repo.GetCommentByID(commentId)
+// DeleteComment soft-deletes the comment and records it in the card history. The author may delete
+// their own comment; a moderator (comment_delete) may delete anyone's, giving a reason.
+func (uc *CardUsecase) DeleteComment(ctx context.Context, actor, commentId uuid.UUID, reason string, moderator bool) error {
+	comment, err := uc.activeComment(ctx, commentId)
 	if err != nil {
 		return err
 	}
-	if comment.AuthorID != authorID {
-		return errs.Unauthorized("unauthorized")
+	reason = strings.TrimSpace(reason)
+	if comment.AuthorID != actor {
+		if !moderator {
+			return ErrNotCommentAuthor
+		}
+		if reason == "" {
+			return ErrDeleteReasonRequired
+		}
 	}
-	return uc.repo.DeleteCommentByID(commentId)
+	now := time.Now()
+	comment.IsActive = false
+	comment.DeletedAt = &now
+	comment.DeletedBy = &actor
+	comment.DeleteReason = reason
+	comment.UpdatedAt = now
+	comment.UpdatedBy = actor
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateCommentDeletion(ctx, comment); err != nil {
+			return err
+		}
+		if err := uc.addCommentHistory(ctx, actor, comment, entities.HistoryCommentDeleted, reason); err != nil {
+			return err
+		}
+		// subscribers get the placeholder, not the removed content
+		comment.Redact()
+		return record(ctx, uc.outbox, EventCommentDeleted, commentCardID(comment), actor, map[string]any{"comment": comment})
+	})
 }
 
-func (uc *CardUsecase) addHistory(actorID, cardID uuid.UUID, statusCode, description string) error {
+// RestoreComment undoes a delete. A moderator may restore any comment; the author only one
+// they deleted themselves. Restoring an active comment changes nothing.
+func (uc *CardUsecase) RestoreComment(ctx context.Context, actor, commentId uuid.UUID, moderator bool) (*entities.CardComment, error) {
+	comment, err := uc.repo.GetCommentByID(ctx, commentId)
+	if err != nil {
+		return nil, dbErr(err, ErrCommentNotFound)
+	}
+	if !moderator {
+		if comment.AuthorID != actor {
+			return nil, ErrNotCommentAuthor
+		}
+		if !comment.IsActive && (comment.DeletedBy == nil || *comment.DeletedBy != actor) {
+			return nil, ErrRestoreNotAllowed
+		}
+	}
+	restored := !comment.IsActive
+	now := time.Now()
+	comment.IsActive = true
+	comment.DeletedAt = nil
+	comment.DeletedBy = nil
+	comment.DeleteReason = ""
+	comment.Render()
+	if err := uc.attachReactions(ctx, []*entities.CardComment{comment}, actor); err != nil {
+		return nil, err
+	}
+	if !restored {
+		return comment, nil
+	}
+	comment.UpdatedAt = now
+	comment.UpdatedBy = actor
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateCommentDeletion(ctx, comment); err != nil {
+			return err
+		}
+		if err := uc.addCommentHistory(ctx, actor, comment, entities.HistoryCommentRestored, ""); err != nil {
+			return err
+		}
+		return record(ctx, uc.outbox, EventCommentRestored, commentCardID(comment), actor, map[string]any{"comment": comment})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// commentCardID parses the comment's card id, which the column stores as a uuid
+func commentCardID(c *entities.CardComment) uuid.UUID {
+	id, _ := uuid.Parse(c.CardID)
+	return id
+}
+
+func (uc *CardUsecase) addHistory(ctx context.Context, actorID, cardID uuid.UUID, action, statusCode, description string) error {
 	p := &entities.CardHistoryLogs{
 		CardID:      cardID,
 		ActorID:     actorID,
+		Action:      action,
 		StatusCode:  statusCode,
 		Description: description,
 		CreatedBy:   actorID,
@@ -140,13 +690,41 @@ func (uc *CardUsecase) addHistory(actorID, cardID uuid.UUID, statusCode, descrip
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-	return uc.repo.AddHistory(p)
+	return uc.repo.AddHistory(ctx, p)
+}
+
+// ListStatuses returns the card statuses with names in lang
+func (uc *CardUsecase) ListStatuses(ctx context.Context, lang string) ([]*entities.CardStatus, error) {
+	list, err := uc.repo.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range list {
+		s.Localize(lang)
+	}
+	return list, nil
 }
 
-func (uc *CardUsecase) ListHistory(cardID string, page, size int) ([]*entities.CardHistoryLogs, int64, error) {
-	return uc.repo.ListHistory(cardID, page, size)
+func (uc *CardUsecase) ListHistory(ctx context.Context, cardID uuid.UUID, page, size int) ([]*entities.CardHistoryLogs, int64, error) {
+	return uc.repo.ListHistory(ctx, cardID, page, size)
 }
 
-func (uc *CardUsecase) Keep(cardID string) error {
-	return uc.repo.DeleteCard(cardID)
+// Keep archives the card; archived cards stay readable by id and are cancelled in calendar feeds
+func (uc *CardUsecase) Keep(ctx context.Context, cardID, actor uuid.UUID) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		card, err := uc.repo.GetForUpdate(ctx, cardID)
+		if err != nil {
+			return dbErr(err, ErrCardNotFound)
+		}
+		if !card.IsActive {
+			return nil
+		}
+		if err := uc.repo.Archive(ctx, cardID, actor); err != nil {
+			return err
+		}
+		card.IsActive = false
+		card.Sequence++
+		card.Render()
+		return record(ctx, uc.outbox, EventCardArchived, cardID, actor, map[string]any{"card": card})
+	})
 }
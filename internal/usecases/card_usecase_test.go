@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"interview-tracker/internal/entities"
+)
+
+func TestApplyPatchSchedule(t *testing.T) {
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	tests := []struct {
+		name      string
+		patch     map[string]any
+		wantStart time.Time
+		wantEnd   time.Time
+		wantSeq   int
+	}{
+		{"start only keeps the length", map[string]any{"scheduled_at": start.Add(24 * time.Hour)}, start.Add(24 * time.Hour), end.Add(24 * time.Hour), 1},
+		{"earlier start keeps the length", map[string]any{"scheduled_at": start.Add(-time.Hour)}, start.Add(-time.Hour), end.Add(-time.Hour), 1},
+		{"start and end", map[string]any{"scheduled_at": start.Add(time.Hour), "ends_at": start.Add(2 * time.Hour)}, start.Add(time.Hour), start.Add(2 * time.Hour), 1},
+		{"end only", map[string]any{"ends_at": start.Add(time.Hour)}, start, start.Add(time.Hour), 1},
+		{"same start", map[string]any{"scheduled_at": start}, start, end, 0},
+		{"no time", map[string]any{"title": "x"}, start, end, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := &entities.Card{ScheduledAt: start, EndsAt: end}
+			applyPatch(card, tt.patch)
+			if !card.ScheduledAt.Equal(tt.wantStart) || !card.EndsAt.Equal(tt.wantEnd) {
+				t.Errorf("slot = %s - %s, want %s - %s", card.ScheduledAt, card.EndsAt, tt.wantStart, tt.wantEnd)
+			}
+			if card.Sequence != tt.wantSeq {
+				t.Errorf("Sequence = %d, want %d", card.Sequence, tt.wantSeq)
+			}
+		})
+	}
+}
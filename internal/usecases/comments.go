@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/markdown"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+// mentionPattern matches @name or @name@domain.tld not preceded by a word character,
+// so an email address inside the text is not read as a mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{M}\p{N}_.@])@([\p{L}\p{M}\p{N}_.+-]+(?:@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)?)`)
+
+const (
+	// mentions past this many in one comment are ignored
+	maxMentions = 20
+	// characters of the comment quoted in a mention notification
+	mentionExcerpt = 200
+)
+
+// parseMentions returns the distinct handles in the prose of content (not in code), lower
+// case, in order of appearance
+func parseMentions(content string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(markdown.Prose(content), -1) {
+		h := strings.ToLower(strings.TrimRight(m[1], ".-_+")) // punctuation ending the sentence
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		out = append(out, h)
+		if len(out) == maxMentions {
+			break
+		}
+	}
+	return out
+}
+
+// resolveMentions maps the handles in content to users: @email matches that email, @name the
+// user whose email starts with name@. A name shared by several users mentions nobody.
+// Only users who can view cards are mentioned, since the notification quotes the comment.
+func (uc *CardUsecase) resolveMentions(ctx context.Context, content string) ([]entities.CommentMention, error) {
+	handles := parseMentions(content)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	users, err := uc.repo.MentionUsers(ctx, handles, "card_view")
+	if err != nil {
+		return nil, err
+	}
+	byEmail := map[string]*entities.User{}
+	byName := map[string][]*entities.User{}
+	for _, u := range users {
+		email := strings.ToLower(u.Email)
+		name, _, _ := strings.Cut(email, "@")
+		byEmail[email] = u
+		byName[name] = append(byName[name], u)
+	}
+	now := time.Now()
+	var out []entities.CommentMention
+	seen := map[uuid.UUID]bool{}
+	for _, h := range handles {
+		u := byEmail[h]
+		if u == nil && len(byName[h]) == 1 {
+			u = byName[h][0]
+		}
+		if u == nil {
+			continue
+		}
+		id, _ := uuid.Parse(u.ID)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, entities.CommentMention{UserID: id, Handle: h, CreatedAt: now})
+	}
+	return out, nil
+}
+
+// newMentions returns the mentions in list that are not in before
+func newMentions(before, list []entities.CommentMention) []entities.CommentMention {
+	had := map[uuid.UUID]bool{}
+	for _, m := range before {
+		had[m.UserID] = true
+	}
+	var out []entities.CommentMention
+	for _, m := range list {
+		if !had[m.UserID] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// notifyMentions tells the mentioned users, except the author, about the comment. It runs after
+// the comment is saved, so a failed notification is logged and not retried.
+func (uc *CardUsecase) notifyMentions(ctx context.Context, cmt *entities.CardComment, author uuid.UUID, mentions []entities.CommentMention) {
+	ids := make([]uuid.UUID, 0, len(mentions)+1)
+	for _, m := range mentions {
+		if m.UserID != author {
+			ids = append(ids, m.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	card, err := uc.repo.GetByID(ctx, commentCardID(cmt))
+	if err != nil {
+		logs.Ctx(ctx).Warnf("notify| mentions in comment %s: %v", cmt.ID, err)
+		return
+	}
+	users, err := uc.repo.Users(ctx, append(ids, author))
+	if err != nil {
+		logs.Ctx(ctx).Warnf("notify| mentions in comment %s: %v", cmt.ID, err)
+		return
+	}
+	n := notify.Notification{Kind: "mention", CardID: card.ID, CreatedAt: time.Now()}
+	authorName := ""
+	for _, u := range users {
+		id, _ := uuid.Parse(u.ID)
+		if id == author {
+			authorName = u.Name
+			continue
+		}
+		n.Recipients = append(n.Recipients, notify.Recipient{UserID: id, Name: u.Name, Email: u.Email})
+	}
+	if len(n.Recipients) == 0 {
+		return
+	}
+	lang := i18n.FromContext(ctx)
+	params := map[string]string{"author": authorName, "title": card.Title, "excerpt": markdown.Excerpt(cmt.Content, mentionExcerpt)}
+	n.Subject = i18n.T(lang, "notify.mention.subject", params)
+	n.Body = i18n.T(lang, "notify.mention.body", params)
+	if err := uc.notifier.Notify(ctx, n); err != nil {
+		logs.Ctx(ctx).Warnf("notify| mentions in comment %s failed: %v", cmt.ID, err)
+		metrics.Notifications.WithLabelValues(n.Kind, "failed").Inc()
+		return
+	}
+	metrics.Notifications.WithLabelValues(n.Kind, "sent").Inc()
+}
+
+// activeComment loads a comment that has not been deleted; a deleted one is not found
+func (uc *CardUsecase) activeComment(ctx context.Context, id uuid.UUID) (*entities.CardComment, error) {
+	c, err := uc.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrCommentNotFound)
+	}
+	if !c.IsActive {
+		return nil, ErrCommentNotFound
+	}
+	return c, nil
+}
+
+// addCommentHistory records a comment delete or restore in the card history, under the card's current status
+func (uc *CardUsecase) addCommentHistory(ctx context.Context, actor uuid.UUID, c *entities.CardComment, action, reason string) error {
+	card, err := uc.repo.GetByID(ctx, commentCardID(c))
+	if err != nil {
+		return dbErr(err, ErrCardNotFound)
+	}
+	now := time.Now()
+	return uc.repo.AddHistory(ctx, &entities.CardHistoryLogs{
+		CardID:      card.ID,
+		ActorID:     actor,
+		Action:      action,
+		CommentID:   &c.ID,
+		Description: reason,
+		StatusCode:  card.StatusCode,
+		CreatedBy:   actor,
+		UpdatedBy:   actor,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+}
+
+// React adds the user's emoji reaction (again is a no-op) and returns the comment's reactions
+func (uc *CardUsecase) React(ctx context.Context, userID, commentID uuid.UUID, emoji string) ([]entities.ReactionSummary, error) {
+	if _, err := uc.activeComment(ctx, commentID); err != nil {
+		return nil, err
+	}
+	r := &entities.CommentReaction{CommentID: commentID, UserID: userID, Emoji: emoji, CreatedAt: time.Now()}
+	if err := uc.repo.AddReaction(ctx, r); err != nil {
+		return nil, err
+	}
+	return uc.reactions(ctx, commentID, userID)
+}
+
+// Unreact removes the user's emoji reaction, if any, and returns the comment's reactions
+func (uc *CardUsecase) Unreact(ctx context.Context, userID, commentID uuid.UUID, emoji string) ([]entities.ReactionSummary, error) {
+	if _, err := uc.activeComment(ctx, commentID); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.RemoveReaction(ctx, commentID, userID, emoji); err != nil {
+		return nil, err
+	}
+	return uc.reactions(ctx, commentID, userID)
+}
+
+func (uc *CardUsecase) reactions(ctx context.Context, commentID, caller uuid.UUID) ([]entities.ReactionSummary, error) {
+	cmt := &entities.CardComment{ID: commentID}
+	if err := uc.attachReactions(ctx, []*entities.CardComment{cmt}, caller); err != nil {
+		return nil, err
+	}
+	return cmt.Reactions, nil
+}
+
+// attachReactions fills Reactions of each comment: one entry per emoji, in the order first used
+func (uc *CardUsecase) attachReactions(ctx context.Context, list []*entities.CardComment, caller uuid.UUID) error {
+	ids := make([]uuid.UUID, 0, len(list))
+	for _, c := range list {
+		ids = append(ids, c.ID)
+	}
+	reactions, err := uc.repo.ListReactions(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byComment := map[uuid.UUID][]entities.ReactionSummary{}
+	for _, r := range reactions {
+		sums := byComment[r.CommentID]
+		i := 0
+		for i < len(sums) && sums[i].Emoji != r.Emoji {
+			i++
+		}
+		if i == len(sums) {
+			sums = append(sums, entities.ReactionSummary{Emoji: r.Emoji})
+		}
+		sums[i].Count++
+		sums[i].UserIDs = append(sums[i].UserIDs, r.UserID)
+		sums[i].Me = sums[i].Me || r.UserID == caller
+		byComment[r.CommentID] = sums
+	}
+	for _, c := range list {
+		c.Reactions = byComment[c.ID]
+		if c.Reactions == nil {
+			c.Reactions = []entities.ReactionSummary{}
+		}
+	}
+	return nil
+}
+
+// ListCommentEdits returns the earlier versions of the comment, newest first. Those of a deleted
+// comment are shown only withDeleted (moderators).
+func (uc *CardUsecase) ListCommentEdits(ctx context.Context, commentID uuid.UUID, withDeleted bool) ([]*entities.CommentEdit, error) {
+	c, err := uc.repo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, dbErr(err, ErrCommentNotFound)
+	}
+	if !c.IsActive && !withDeleted {
+		return nil, ErrCommentNotFound
+	}
+	list, err := uc.repo.ListCommentEdits(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range list {
+		e.Render()
+	}
+	return list, nil
+}
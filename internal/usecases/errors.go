@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"interview-tracker/internal/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+// domain errors returned to handlers; the code is part of the API contract
+var (
+	ErrCardNotFound         = errs.NotFound("card not found").WithCode("card_not_found")
+	ErrCommentNotFound      = errs.NotFound("comment not found").WithCode("comment_not_found")
+	ErrUserNotFound         = errs.NotFound("user not found").WithCode("user_not_found")
+	ErrRoleNotFound         = errs.NotFound("role not found").WithCode("role_not_found")
+	ErrApiKeyNotFound       = errs.NotFound("api key not found").WithCode("api_key_not_found")
+	ErrCalendarFeedNotFound = errs.NotFound("calendar feed not found").WithCode("calendar_feed_not_found")
+	ErrWebhookNotFound      = errs.NotFound("webhook not found").WithCode("webhook_not_found")
+	ErrDeliveryNotFound     = errs.NotFound("webhook delivery not found").WithCode("webhook_delivery_not_found")
+	ErrAttachmentNotFound   = errs.NotFound("attachment not found").WithCode("attachment_not_found")
+	ErrEmailTaken           = errs.Conflict("email already in use").WithCode("email_taken")
+	ErrInvalidStatus        = errs.Validation("invalid status", nil).WithCode("invalid_status")
+	ErrNotCommentAuthor     = errs.Forbidden("only the author can change this comment").WithCode("not_comment_author")
+	ErrDeleteReasonRequired = errs.Validation("give a reason to delete someone else's comment", nil).WithCode("delete_reason_required")
+	ErrRestoreNotAllowed    = errs.Forbidden("only a moderator can restore a comment removed by someone else").WithCode("comment_restore_forbidden")
+	ErrNotAttachmentOwner   = errs.Forbidden("only the uploader can delete this attachment").WithCode("not_attachment_uploader")
+	ErrAttachmentTooLarge   = errs.New(http.StatusRequestEntityTooLarge, "attachment_too_large", "the file is larger than the upload limit")
+	ErrAttachmentEmpty      = errs.Validation("the file is empty", nil).WithCode("attachment_empty")
+	ErrAttachmentType       = errs.New(http.StatusUnsupportedMediaType, "attachment_type_not_allowed", "this type of file cannot be attached")
+	ErrInvalidDownloadToken = errs.Unauthorized("the download link is invalid or has expired").WithCode("invalid_download_token")
+
+	ErrInvalidTimeRange     = errs.Validation("the end time must be after the start time", nil).WithCode("invalid_time_range")
+	ErrScheduleConflict     = errs.Conflict("an interviewer is already booked at this time").WithCode("schedule_conflict")
+	ErrCardArchived         = errs.Conflict("the card is archived").WithCode("card_archived")
+	ErrMoveAnchors          = errs.Validation("give after_id or before_id of another card, not both", nil).WithCode("move_anchors")
+	ErrMoveAnchorMissing    = errs.Conflict("the card to place next to is not in that column").WithCode("move_anchor_missing")
+	ErrCalendarRangeTooLong = errs.Validation("calendar range is too long", nil).WithCode("calendar_range_too_long").WithParam("days", strconv.Itoa(int(maxCalendarRange/(24*time.Hour))))
+
+	ErrInvalidCredentials    = errs.Unauthorized("invalid email or password").WithCode("invalid_credentials")
+	ErrInvalidRefreshToken   = errs.Unauthorized("invalid or expired refresh token").WithCode("invalid_refresh_token")
+	ErrInvalidApiKey         = errs.Unauthorized("invalid api key").WithCode("invalid_api_key")
+	ErrSSOFailed             = errs.Unauthorized("sso login failed").WithCode("sso_failed")
+	ErrSSOUserNotProvisioned = errs.Forbidden("user is not provisioned for sso").WithCode("sso_user_not_provisioned")
+	ErrSSOAccountDisabled    = errs.Forbidden("user account is disabled").WithCode("account_disabled")
+)
+
+// dbErr maps GORM sentinel errors onto domain errors: record-not-found (or a
+// foreign key pointing at a missing parent) becomes notFound and unique
+// violations become a conflict. Anything else is returned
+// unchanged and rendered as internal_error.
+func dbErr(err error, notFound *errs.HttpError) error {
+	switch {
+	case err == nil:
+		return nil
+	case (errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, gorm.ErrForeignKeyViolated)) && notFound != nil:
+		return notFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return errs.Conflict("resource already exists")
+	}
+	return err
+}
+
+// ErrLoginLocked is returned while an email is locked out from the client IP after too many failed logins
+func ErrLoginLocked(retryAfter int) *errs.HttpError {
+	e := errs.New(http.StatusTooManyRequests, "login_locked", "Too many failed logins. Please try again later.")
+	e.RetryAfter = retryAfter
+	return e
+}
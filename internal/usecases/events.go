@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+
+	"github.com/google/uuid"
+)
+
+// event types recorded on card, comment and attachment changes; webhooks subscribe to these names
+const (
+	EventCardCreated       = "card.created"
+	EventCardUpdated       = "card.updated"
+	EventCardStatusChanged = "card.status_changed"
+	EventCardMoved         = "card.moved" // reordered within its status column
+	EventCardArchived      = "card.archived"
+	EventCommentCreated    = "comment.created"
+	EventCommentUpdated    = "comment.updated"
+	EventCommentDeleted    = "comment.deleted" // soft delete; data is the placeholder
+	EventCommentRestored   = "comment.restored"
+	EventAttachmentAdded   = "attachment.added"
+	EventAttachmentDeleted = "attachment.deleted"
+)
+
+// EventTypes lists every event type, in the order they are documented
+var EventTypes = []string{
+	EventCardCreated, EventCardUpdated, EventCardStatusChanged, EventCardMoved, EventCardArchived,
+	EventCommentCreated, EventCommentUpdated, EventCommentDeleted, EventCommentRestored,
+	EventAttachmentAdded, EventAttachmentDeleted,
+}
+
+// record writes an event to the outbox. Call it inside the transaction that makes the change:
+// the event is published only if the change commits, and is not lost once it has.
+func record(ctx context.Context, outbox repositories.OutboxRepository, typ string, cardID, actor uuid.UUID, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	return outbox.Add(ctx, &entities.OutboxEvent{
+		EventID:       uuid.New(),
+		CardID:        cardID,
+		EventType:     typ,
+		ActorID:       actor,
+		Payload:       payload,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	})
+}
@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/pkg/buildinfo"
+	"interview-tracker/internal/pkg/logs"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// HealthCheck is public (the readiness endpoint is unauthenticated), so it carries no error
+// text or detail; those are logged instead since they can name hosts and ports
+type HealthCheck struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type Readiness struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks"`
+	Build  buildinfo.Info         `json:"build"`
+}
+
+type HealthUsecase struct {
+	db              *gorm.DB
+	rdb             *redis.Client
+	keys            config.JWTKeys
+	expectedVersion uint
+	timeout         time.Duration
+}
+
+func NewHealthUsecase(db *gorm.DB, rdb *redis.Client, keys config.JWTKeys, migrationsPath string, timeout time.Duration) *HealthUsecase {
+	return &HealthUsecase{
+		db:              db,
+		rdb:             rdb,
+		keys:            keys,
+		expectedVersion: latestMigrationVersion(migrationsPath),
+		timeout:         timeout,
+	}
+}
+
+// Ready runs every dependency check concurrently, each bounded by its own timeout.
+func (uc *HealthUsecase) Ready(ctx context.Context) Readiness {
+	checks := map[string]func(context.Context) (string, error){
+		"database":  uc.checkDatabase,
+		"redis":     uc.checkRedis,
+		"migration": uc.checkMigration,
+		"jwt_keys":  uc.checkJWTKeys,
+	}
+
+	res := Readiness{Status: HealthOK, Checks: map[string]HealthCheck{}, Build: buildinfo.Get()}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, fn := range checks {
+		wg.Add(1)
+		go func(name string, fn func(context.Context) (string, error)) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, uc.timeout)
+			defer cancel()
+
+			start := time.Now()
+			detail, err := fn(cctx)
+			hc := HealthCheck{Status: HealthOK, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				hc.Status = HealthFail
+				logs.Ctx(ctx).Warnf("[health] %s check failed: %v (%s)", name, err, detail)
+			}
+
+			mu.Lock()
+			res.Checks[name] = hc
+			if err != nil {
+				res.Status = HealthFail
+			}
+			mu.Unlock()
+		}(name, fn)
+	}
+	wg.Wait()
+	return res
+}
+
+func (uc *HealthUsecase) checkDatabase(ctx context.Context) (string, error) {
+	sqlDB, err := uc.db.DB()
+	if err != nil {
+		return "", err
+	}
+	return "", sqlDB.PingContext(ctx)
+}
+
+func (uc *HealthUsecase) checkRedis(ctx context.Context) (string, error) {
+	return "", uc.rdb.Ping(ctx).Err()
+}
+
+func (uc *HealthUsecase) checkMigration(ctx context.Context) (string, error) {
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	if err := uc.db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row).Error; err != nil {
+		return "", err
+	}
+	detail := fmt.Sprintf("version %d (expected %d)", row.Version, uc.expectedVersion)
+	if row.Dirty {
+		return detail, errors.New("migration is dirty")
+	}
+	if row.Version < uc.expectedVersion {
+		return detail, errors.New("migration behind")
+	}
+	return detail, nil
+}
+
+func (uc *HealthUsecase) checkJWTKeys(ctx context.Context) (string, error) {
+	if uc.keys.Public == nil {
+		return "", errors.New("public key not loaded")
+	}
+	if uc.keys.Private == nil {
+		return "verify only", nil
+	}
+	if err := uc.keys.Private.Validate(); err != nil {
+		return "", err
+	}
+	return "sign and verify", nil
+}
+
+// latestMigrationVersion returns the highest NNN_ prefix in a file:// migrations dir
+func latestMigrationVersion(path string) uint {
+	entries, err := os.ReadDir(strings.TrimPrefix(path, "file://"))
+	if err != nil {
+		return 0
+	}
+	var max uint
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.ParseUint(prefix, 10, 64); err == nil && uint(v) > max {
+			max = uint(v)
+		}
+	}
+	return max
+}
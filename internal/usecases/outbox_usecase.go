@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/events"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// each relay run publishes at most this many outbox events; the rest wait for the next run
+	relayBatchSize = 500
+	// events published per transaction; a transaction that does not commit (the run hit its
+	// deadline) only repeats the sink calls of its own chunk
+	relayChunkSize = 20
+)
+
+// OutboxRelay publishes outbox events to the configured sinks: at least once, in order per card.
+// A sink that fails gets the event again on a later run (with backoff); sinks that already
+// accepted it are not called again, and later events of the same card wait behind it.
+type OutboxRelay struct {
+	tx        repositories.Transactor
+	repo      repositories.OutboxRepository
+	sinks     []events.NamedSink
+	retention time.Duration
+}
+
+func NewOutboxRelay(tx repositories.Transactor, r repositories.OutboxRepository, sinks []events.NamedSink, retention time.Duration) *OutboxRelay {
+	return &OutboxRelay{tx: tx, repo: r, sinks: sinks, retention: retention}
+}
+
+// Relay publishes up to relayBatchSize events, relayChunkSize per transaction. Each transaction
+// holds the relay lock, so two replicas never publish concurrently, and commits what its chunk
+// published, so a run cut short by its deadline keeps the chunks before it.
+func (uc *OutboxRelay) Relay(ctx context.Context) error {
+	for n := 0; n < relayBatchSize && ctx.Err() == nil; n += relayChunkSize {
+		more, err := uc.relayChunk(ctx)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// relayChunk publishes the next pending events in one transaction and reports whether more may
+// be waiting. Sinks that write to the database (webhooks) join that transaction.
+func (uc *OutboxRelay) relayChunk(ctx context.Context) (bool, error) {
+	more := false
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if ok, err := uc.repo.TryLock(ctx); err != nil || !ok {
+			return err
+		}
+		list, err := uc.repo.Pending(ctx, time.Now(), relayChunkSize)
+		if err != nil {
+			return err
+		}
+		more = len(list) == relayChunkSize
+		blocked := map[uuid.UUID]bool{}
+		for _, e := range list {
+			if blocked[e.CardID] {
+				continue
+			}
+			if !uc.publish(ctx, e) {
+				blocked[e.CardID] = true
+			}
+			if err := uc.repo.Save(ctx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return more, err
+}
+
+// publish offers e to every sink that has not accepted it yet and reports whether all have
+func (uc *OutboxRelay) publish(ctx context.Context, e *entities.OutboxEvent) bool {
+	ev := events.Event{
+		ID:        e.EventID,
+		Type:      e.EventType,
+		Sequence:  e.ID,
+		CardID:    e.CardID,
+		ActorID:   e.ActorID,
+		CreatedAt: e.CreatedAt,
+		Data:      e.Payload,
+	}
+	for _, s := range uc.sinks {
+		if slices.Contains(e.SinksDone, s.Name) {
+			continue
+		}
+		// a savepoint per sink keeps a failed database write from aborting the whole chunk
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error { return s.Sink.Publish(ctx, ev) })
+		if err != nil {
+			metrics.OutboxEvents.WithLabelValues(s.Name, "retry").Inc()
+			logs.Logger.Errorf("outbox| %s %s to %s failed (attempt %d): %v", e.EventType, e.EventID, s.Name, e.Attempts+1, err)
+			e.Attempts++
+			e.LastError = s.Name + ": " + err.Error()
+			e.NextAttemptAt = time.Now().Add(retryDelay(e.Attempts))
+			return false
+		}
+		metrics.OutboxEvents.WithLabelValues(s.Name, "published").Inc()
+		e.SinksDone = append(e.SinksDone, s.Name)
+	}
+	now := time.Now()
+	e.PublishedAt = &now
+	e.LastError = ""
+	return true
+}
+
+// Cleanup deletes events published longer ago than the retention period
+func (uc *OutboxRelay) Cleanup(ctx context.Context) error {
+	n, err := uc.repo.DeletePublished(ctx, time.Now().Add(-uc.retention))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		logs.Logger.Printf("outbox| deleted %d published events", n)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// memOutbox keeps events in memory; Pending applies the same filter as the SQL in outboxRepo
+type memOutbox struct {
+	repositories.OutboxRepository
+	list  []*entities.OutboxEvent
+	saved int
+}
+
+func (m *memOutbox) add(card uuid.UUID) *entities.OutboxEvent {
+	e := &entities.OutboxEvent{ID: int64(len(m.list) + 1), EventID: uuid.New(), CardID: card, EventType: "card.updated"}
+	m.list = append(m.list, e)
+	return e
+}
+
+func (m *memOutbox) TryLock(context.Context) (bool, error) { return true, nil }
+
+func (m *memOutbox) Pending(_ context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	var out []*entities.OutboxEvent
+	for _, e := range m.list {
+		if e.PublishedAt != nil || e.NextAttemptAt.After(now) {
+			continue
+		}
+		waiting := slices.ContainsFunc(m.list, func(p *entities.OutboxEvent) bool {
+			return p.CardID == e.CardID && p.PublishedAt == nil && p.ID < e.ID && p.NextAttemptAt.After(now)
+		})
+		if !waiting && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memOutbox) Save(context.Context, *entities.OutboxEvent) error {
+	m.saved++
+	return nil
+}
+
+// fakeSink records what it accepted and rejects events for which fail returns true
+type fakeSink struct {
+	got  []uuid.UUID
+	fail func(events.Event) bool
+}
+
+func (s *fakeSink) Publish(_ context.Context, ev events.Event) error {
+	if s.fail != nil && s.fail(ev) {
+		return errors.New("sink down")
+	}
+	s.got = append(s.got, ev.ID)
+	return nil
+}
+
+func TestOutboxRelay(t *testing.T) {
+	cardA, cardB := uuid.New(), uuid.New()
+	repo := &memOutbox{}
+	a1, b1, a2 := repo.add(cardA), repo.add(cardB), repo.add(cardA)
+
+	first := &fakeSink{}
+	flaky := &fakeSink{fail: func(ev events.Event) bool { return ev.CardID == cardA }}
+	relay := NewOutboxRelay(noTx{}, repo, []events.NamedSink{{Name: "log", Sink: first}, {Name: "webhooks", Sink: flaky}}, time.Hour)
+
+	if err := relay.Relay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// a1 failed on webhooks: a2 waits behind it, b1 goes through
+	if want := []uuid.UUID{a1.EventID, b1.EventID}; !slices.Equal(first.got, want) {
+		t.Errorf("first sink got %v, want %v", first.got, want)
+	}
+	if want := []uuid.UUID{b1.EventID}; !slices.Equal(flaky.got, want) {
+		t.Errorf("flaky sink got %v, want %v", flaky.got, want)
+	}
+	if a1.PublishedAt != nil || a1.Attempts != 1 || !a1.NextAttemptAt.After(time.Now()) || a1.LastError == "" {
+		t.Errorf("failed event: %+v", a1)
+	}
+	if !slices.Equal(a1.SinksDone, []string{"log"}) {
+		t.Errorf("a1 sinks done = %v, want [log]", a1.SinksDone)
+	}
+	if b1.PublishedAt == nil || !slices.Equal(b1.SinksDone, []string{"log", "webhooks"}) {
+		t.Errorf("b1 not published: %+v", b1)
+	}
+	if a2.PublishedAt != nil || a2.Attempts != 0 || len(a2.SinksDone) != 0 {
+		t.Errorf("a2 was offered to a sink before a1: %+v", a2)
+	}
+
+	// before a1 is due again nothing of cardA is sent, not even a2
+	if err := relay.Relay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(first.got) != 2 || len(flaky.got) != 1 {
+		t.Errorf("sinks called before the retry was due: %v, %v", first.got, flaky.got)
+	}
+
+	// once due and the sink is back, a1 goes only to the sink that missed it, then a2 follows
+	flaky.fail = nil
+	a1.NextAttemptAt = time.Now().Add(-time.Second)
+	if err := relay.Relay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []uuid.UUID{a1.EventID, b1.EventID, a2.EventID}; !slices.Equal(first.got, want) {
+		t.Errorf("first sink got %v, want %v (a1 must not be sent twice)", first.got, want)
+	}
+	if want := []uuid.UUID{b1.EventID, a1.EventID, a2.EventID}; !slices.Equal(flaky.got, want) {
+		t.Errorf("flaky sink got %v, want %v", flaky.got, want)
+	}
+	if a1.PublishedAt == nil || a1.LastError != "" || a2.PublishedAt == nil {
+		t.Errorf("cardA events not published: %+v, %+v", a1, a2)
+	}
+}
+
+func TestOutboxRelayStopsCardAtFirstFailingSink(t *testing.T) {
+	card := uuid.New()
+	repo := &memOutbox{}
+	e := repo.add(card)
+
+	down := &fakeSink{fail: func(events.Event) bool { return true }}
+	after := &fakeSink{}
+	relay := NewOutboxRelay(noTx{}, repo, []events.NamedSink{{Name: "down", Sink: down}, {Name: "after", Sink: after}}, time.Hour)
+	if err := relay.Relay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(after.got) != 0 || len(e.SinksDone) != 0 || e.Attempts != 1 {
+		t.Errorf("later sink called after an earlier one failed: %v, %+v", after.got, e)
+	}
+	if repo.saved != 1 {
+		t.Errorf("saved %d times, want 1", repo.saved)
+	}
+}
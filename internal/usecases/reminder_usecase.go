@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/i18n"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+// each job run handles at most this many cards per lead time / status; the rest wait for the next run
+const notifyBatchSize = 200
+
+type ReminderUsecase struct {
+	repo     repositories.NotificationRepository
+	notifier notify.Notifier
+	// leads are sorted ascending, e.g. [1h, 24h]
+	leads      []time.Duration
+	staleAfter map[string]time.Duration
+}
+
+func NewReminderUsecase(r repositories.NotificationRepository, n notify.Notifier, leads []time.Duration, staleAfter map[string]time.Duration) *ReminderUsecase {
+	sorted := append([]time.Duration(nil), leads...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return &ReminderUsecase{repo: r, notifier: n, leads: sorted, staleAfter: staleAfter}
+}
+
+// SendReminders notifies interviewers and the card owner ahead of each interview, once per lead time.
+// Scheduled notifications have no request to take a language from and users store none, so
+// they are written in DEFAULT_LANGUAGE (one message goes to all of a card's recipients).
+// A card only gets the shortest lead it still fits in: one created 30 minutes ahead
+// receives the 1h reminder, not the 1h and the 24h reminder together.
+func (uc *ReminderUsecase) SendReminders(ctx context.Context) error {
+	now := time.Now()
+	prev := time.Duration(0)
+	for _, lead := range uc.leads {
+		kind := "reminder_" + formatLead(lead)
+		cards, err := uc.repo.DueReminders(ctx, kind, now.Add(prev), now.Add(lead), notifyBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			lang := i18n.Default()
+			params := map[string]string{"title": c.Title, "lead": formatLead(lead), "start": startText(c)}
+			n := notify.Notification{
+				Kind:    "reminder",
+				CardID:  c.ID,
+				Subject: i18n.T(lang, "notify.reminder.subject", params),
+				Body:    i18n.T(lang, "notify.reminder.body", params),
+			}
+			if err := uc.deliver(ctx, c, kind, c.ScheduledAt, n); err != nil {
+				return err
+			}
+		}
+		prev = lead
+	}
+	return nil
+}
+
+// AlertStaleCards notifies the owner and interviewers of cards that have not changed
+// for longer than their status allows. The alert repeats only after the card is touched again.
+// Like reminders, alerts are written in DEFAULT_LANGUAGE.
+func (uc *ReminderUsecase) AlertStaleCards(ctx context.Context) error {
+	now := time.Now()
+	for status, after := range uc.staleAfter {
+		kind := "stale_" + status
+		cards, err := uc.repo.StaleCards(ctx, kind, status, now.Add(-after), notifyBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			lang := i18n.Default()
+			params := map[string]string{
+				"title":  c.Title,
+				"status": status,
+				"days":   fmt.Sprintf("%d", int(now.Sub(c.UpdatedAt).Hours()/24)),
+			}
+			n := notify.Notification{
+				Kind:    "stale_card",
+				CardID:  c.ID,
+				Subject: i18n.T(lang, "notify.stale.subject", params),
+				Body:    i18n.T(lang, "notify.stale.body", params),
+			}
+			if err := uc.deliver(ctx, c, kind, c.UpdatedAt, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deliver sends n to the card's people and records it. A failed delivery is logged
+// and left unrecorded so the next run retries it; only repository errors abort the run.
+func (uc *ReminderUsecase) deliver(ctx context.Context, c *entities.Card, kind string, ref time.Time, n notify.Notification) error {
+	users, err := uc.repo.Users(ctx, recipientIDs(c))
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		id, _ := uuid.Parse(u.ID)
+		n.Recipients = append(n.Recipients, notify.Recipient{UserID: id, Name: u.Name, Email: u.Email})
+	}
+	if len(n.Recipients) == 0 {
+		return nil
+	}
+	n.CreatedAt = time.Now()
+	if err := uc.notifier.Notify(ctx, n); err != nil {
+		logs.Ctx(ctx).Warnf("notify| %s for card %s failed: %v", kind, c.ID, err)
+		metrics.Notifications.WithLabelValues(n.Kind, "failed").Inc()
+		return nil
+	}
+	metrics.Notifications.WithLabelValues(n.Kind, "sent").Inc()
+	return uc.repo.MarkSent(ctx, &entities.CardNotification{CardID: c.ID, Kind: kind, RefTime: ref, SentAt: n.CreatedAt})
+}
+
+// recipientIDs is the card owner plus its interviewers
+func recipientIDs(c *entities.Card) []uuid.UUID {
+	ids := c.InterviewerIDs()
+	if c.CreatedBy != uuid.Nil {
+		ids = append(ids, c.CreatedBy)
+	}
+	return ids
+}
+
+// startText is the start time in the card's own time zone
+func startText(c *entities.Card) string {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return c.ScheduledAt.In(loc).Format("2006-01-02 15:04 MST")
+}
+
+// formatLead prints 24h, 90m, 30m as "24h", "1h30m", "30m"
+func formatLead(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/pkg/token"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// SSOUsecase implements the OIDC authorization code flow with PKCE.
+// A successful callback ends in the same redis session + token pair as password login.
+type SSOUsecase interface {
+	AuthURL(ctx context.Context) (string, error)
+	Callback(ctx context.Context, state, code string) (accessToken, refreshToken, refID string, err error)
+}
+
+type ssoUsecase struct {
+	auth     *authUsecase
+	userRepo repositories.UserRepository
+	roleRepo repositories.RoleRepository
+	rdb      *redis.Client
+	cfg      config.OIDCConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewSSOUsecase(userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, rdb *redis.Client, cfg config.OIDCConfig) SSOUsecase {
+	return &ssoUsecase{
+		auth:     &authUsecase{userRepo: userRepo, rdb: rdb},
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+		rdb:      rdb,
+		cfg:      cfg,
+	}
+}
+
+// pending login kept in redis between AuthURL and Callback
+type ssoState struct {
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+const ssoStateTTL = 10 * time.Minute
+
+// ===== main flows =====
+
+func (s *ssoUsecase) AuthURL(ctx context.Context) (string, error) {
+	oauthCfg, _, err := s.oauthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := token.NewRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := token.NewRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	st := ssoState{Verifier: oauth2.GenerateVerifier(), Nonce: nonce}
+	if err := s.rdb.Set(ctx, "sso:state:"+state, token.MustJSON(st), ssoStateTTL).Err(); err != nil {
+		return "", err
+	}
+
+	return oauthCfg.AuthCodeURL(state, oidc.Nonce(st.Nonce), oauth2.S256ChallengeOption(st.Verifier)), nil
+}
+
+func (s *ssoUsecase) Callback(ctx context.Context, state, code string) (string, string, string, error) {
+	// state is single use
+	raw, err := s.rdb.GetDel(ctx, "sso:state:"+state).Result()
+	if err != nil || raw == "" {
+		return "", "", "", errors.New("invalid or expired sso state")
+	}
+	var st ssoState
+	if err := json.Unmarshal([]byte(raw), &st); err != nil {
+		return "", "", "", err
+	}
+
+	oauthCfg, provider, err := s.oauthConfig(ctx)
+	if err != nil {
+		return "", "", "", err
+	}
+	tok, err := oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(st.Verifier))
+	if err != nil {
+		return "", "", "", err
+	}
+	rawIDToken, ok := tok.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return "", "", "", errors.New("id_token missing from token response")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return "", "", "", err
+	}
+	if idToken.Nonce != st.Nonce {
+		return "", "", "", errors.New("invalid nonce")
+	}
+
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return "", "", "", err
+	}
+	email, _ := claims["email"].(string)
+	email = strings.TrimSpace(strings.ToLower(email))
+	if email == "" {
+		return "", "", "", errors.New("email claim missing")
+	}
+	// the email links the login to a local account, so an unverified one could take it over
+	if verified, _ := claims["email_verified"].(bool); !verified && !s.cfg.TrustEmail {
+		return "", "", "", errors.New("email not verified")
+	}
+	name, _ := claims["name"].(string)
+
+	u, err := s.resolveUser(ctx, email, name, claimStrings(claims[s.cfg.GroupsClaim]))
+	if err != nil {
+		return "", "", "", err
+	}
+	return s.auth.issueSession(ctx, u)
+}
+
+// resolveUser maps the IdP identity onto a local user, optionally creating it (JIT provisioning).
+func (s *ssoUsecase) resolveUser(ctx context.Context, email, name string, groups []string) (*entities.User, error) {
+	roleCode := s.mapRole(groups)
+
+	u, err := s.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		// a deactivated account is refused, never provisioned again
+		if !u.IsActive {
+			return nil, ErrSSOAccountDisabled
+		}
+		if s.cfg.SyncRole && roleCode != "" && (u.Role == nil || u.Role.Code != roleCode) {
+			role, err := s.roleRepo.GetByCode(ctx, roleCode)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.userRepo.UpdateRole(ctx, u.ID, role.ID); err != nil {
+				return nil, err
+			}
+			u.RoleID, u.Role = role.ID, role
+		}
+		return u, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if !s.cfg.AutoProvision {
+		return nil, ErrSSOUserNotProvisioned
+	}
+
+	if roleCode == "" {
+		roleCode = s.cfg.DefaultRole
+	}
+	role, err := s.roleRepo.GetByCode(ctx, roleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// sso users never log in with a password; store an unguessable hash
+	random, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(random), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = email
+	}
+
+	var currentDtm = time.Now()
+	created, err := s.userRepo.Create(ctx, entities.User{
+		Name:      name,
+		Email:     email,
+		Password:  string(hashed),
+		RoleID:    role.ID,
+		IsActive:  true,
+		CreatedAt: currentDtm,
+		UpdatedAt: currentDtm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created.Role = role
+	return created, nil
+}
+
+// mapRole returns the highest-privilege role (earliest in RolePriority) mapped from the
+// user's IdP groups, or "" when none match. The IdP's group order does not matter, so a user
+// in several mapped groups always gets the same role.
+func (s *ssoUsecase) mapRole(groups []string) string {
+	best, bestRank := "", len(s.cfg.RolePriority)
+	for _, g := range groups {
+		code, ok := s.cfg.RoleMapping[g]
+		if !ok {
+			continue
+		}
+		if rank := slices.Index(s.cfg.RolePriority, code); rank >= 0 && rank < bestRank {
+			best, bestRank = code, rank
+		}
+	}
+	return best
+}
+
+// oauthConfig discovers the provider lazily so the service can start while the IdP is down.
+func (s *ssoUsecase) oauthConfig(ctx context.Context) (*oauth2.Config, *oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider == nil {
+		p, err := oidc.NewProvider(ctx, s.cfg.IssuerURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.provider = p
+	}
+	return &oauth2.Config{
+		ClientID:     s.cfg.ClientID,
+		ClientSecret: s.cfg.ClientSecret,
+		RedirectURL:  s.cfg.RedirectURL,
+		Endpoint:     s.provider.Endpoint(),
+		Scopes:       s.cfg.Scopes,
+	}, s.provider, nil
+}
+
+// ===== helpers =====
+func claimStrings(v any) []string {
+	switch x := v.(type) {
+	case string:
+		return []string{x}
+	case []any:
+		out := make([]string, 0, len(x))
+		for _, e := range x {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,461 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// mockIdP is an in-process OIDC provider: discovery, JWKS and a token endpoint that
+// checks the PKCE verifier. Logins are "approved" with authorize instead of a browser.
+type mockIdP struct {
+	srv *httptest.Server
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]mockGrant // code -> grant
+}
+
+type mockGrant struct {
+	challenge string
+	nonce     string
+	claims    map[string]any
+}
+
+const mockClientID = "interview-tracker"
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{key: key, grants: map[string]mockGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"issuer":                                idp.srv.URL,
+			"authorization_endpoint":                idp.srv.URL + "/authorize",
+			"token_endpoint":                        idp.srv.URL + "/token",
+			"jwks_uri":                              idp.srv.URL + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+			"code_challenge_methods_supported":      []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub := idp.key.PublicKey
+		writeJSON(w, http.StatusOK, map[string]any{"keys": []map[string]any{{
+			"kty": "RSA", "alg": "RS256", "use": "sig", "kid": "test",
+			"n": base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", idp.token)
+	idp.srv = httptest.NewServer(mux)
+	t.Cleanup(idp.srv.Close)
+	return idp
+}
+
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_request"})
+		return
+	}
+	idp.mu.Lock()
+	g, ok := idp.grants[r.Form.Get("code")]
+	delete(idp.grants, r.Form.Get("code"))
+	idp.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+		return
+	}
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   idp.srv.URL,
+		"aud":   mockClientID,
+		"sub":   uuid.NewString(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+		"nonce": g.nonce,
+	}
+	for k, v := range g.claims {
+		claims[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "test"
+	idToken, err := tok.SignedString(idp.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]any{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "at", "token_type": "Bearer", "expires_in": 60, "id_token": idToken,
+	})
+}
+
+// authorize approves the login started by authURL for a user with claims and returns the
+// state and code the browser would bring back to the callback
+func (idp *mockIdP) authorize(t *testing.T, authURL string, claims map[string]any) (state, code string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Fatalf("auth URL without S256 PKCE challenge: %s", authURL)
+	}
+	if q.Get("state") == "" || q.Get("nonce") == "" {
+		t.Fatalf("auth URL without state or nonce: %s", authURL)
+	}
+	code = uuid.NewString()
+	idp.mu.Lock()
+	idp.grants[code] = mockGrant{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), claims: claims}
+	idp.mu.Unlock()
+	return q.Get("state"), code
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type fakeUserRepo struct {
+	mu    sync.Mutex
+	users map[string]*entities.User // by email
+}
+
+func (r *fakeUserRepo) Create(_ context.Context, u entities.User) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u.ID = uuid.NewString()
+	r.users[u.Email] = &u
+	return &u, nil
+}
+
+func (r *fakeUserRepo) GetById(_ context.Context, id string) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	u, err := r.FindByEmail(ctx, email)
+	if err == nil && !u.IsActive {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return u, err
+}
+
+func (r *fakeUserRepo) FindByEmail(_ context.Context, email string) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[email]; ok {
+		return u, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) GetPermissionsByUserID(context.Context, string) ([]string, error) {
+	return []string{"card_view"}, nil
+}
+
+func (r *fakeUserRepo) UpdateRole(_ context.Context, userID, roleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.ID == userID {
+			u.RoleID = roleID
+		}
+	}
+	return nil
+}
+
+type fakeRoleRepo struct{}
+
+func (fakeRoleRepo) GetList(context.Context) ([]*entities.Role, error) { return nil, nil }
+func (fakeRoleRepo) ExistsByID(context.Context, string) (bool, error)  { return true, nil }
+func (fakeRoleRepo) GetPermissionList(context.Context) ([]*entities.Permission, error) {
+	return nil, nil
+}
+func (fakeRoleRepo) GetByCode(_ context.Context, code string) (*entities.Role, error) {
+	return &entities.Role{ID: "role-" + code, Code: code}, nil
+}
+
+// useTestConfig installs a config with fresh signing keys for code that reads config.EnvConfig
+func useTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := config.EnvConfig
+	config.EnvConfig = &config.Config{
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: time.Hour,
+		JWTKeys:    config.JWTKeys{Private: key, Public: &key.PublicKey},
+	}
+	t.Cleanup(func() { config.EnvConfig = prev })
+	return config.EnvConfig
+}
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return mr, rdb
+}
+
+type ssoTest struct {
+	uc    SSOUsecase
+	idp   *mockIdP
+	users *fakeUserRepo
+	rdb   *redis.Client
+}
+
+func newSSOTest(t *testing.T, edit func(*config.OIDCConfig)) *ssoTest {
+	t.Helper()
+	useTestConfig(t)
+	_, rdb := newTestRedis(t)
+	idp := newMockIdP(t)
+	cfg := config.OIDCConfig{
+		IssuerURL:     idp.srv.URL,
+		ClientID:      mockClientID,
+		ClientSecret:  "secret",
+		RedirectURL:   "http://localhost/callback",
+		Scopes:        []string{"openid", "email", "profile"},
+		GroupsClaim:   "groups",
+		RoleMapping:   map[string]string{"tracker-admins": "admin", "tracker-editors": "editor"},
+		RolePriority:  []string{"admin", "editor", "viewer"},
+		DefaultRole:   "viewer",
+		AutoProvision: true,
+		SyncRole:      true,
+	}
+	if edit != nil {
+		edit(&cfg)
+	}
+	users := &fakeUserRepo{users: map[string]*entities.User{}}
+	return &ssoTest{uc: NewSSOUsecase(users, fakeRoleRepo{}, rdb, cfg), idp: idp, users: users, rdb: rdb}
+}
+
+// login runs the whole flow for a user with claims and returns the callback's error
+func (st *ssoTest) login(t *testing.T, claims map[string]any) error {
+	t.Helper()
+	ctx := context.Background()
+	authURL, err := st.uc.AuthURL(ctx)
+	if err != nil {
+		t.Fatalf("AuthURL: %v", err)
+	}
+	state, code := st.idp.authorize(t, authURL, claims)
+	_, _, _, err = st.uc.Callback(ctx, state, code)
+	return err
+}
+
+func verifiedUser(email string, groups ...string) map[string]any {
+	return map[string]any{"email": email, "email_verified": true, "name": "Test User", "groups": groups}
+}
+
+func TestSSOProvisionsUserWithMappedRole(t *testing.T) {
+	st := newSSOTest(t, nil)
+	if err := st.login(t, verifiedUser("New.User@example.com", "tracker-editors", "tracker-admins")); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	u := st.users.users["new.user@example.com"]
+	if u == nil {
+		t.Fatal("user was not provisioned")
+	}
+	if u.RoleID != "role-admin" || !u.IsActive || u.Name != "Test User" {
+		t.Errorf("provisioned user = %+v, want active admin named Test User", u)
+	}
+}
+
+func TestSSOWithoutAutoProvision(t *testing.T) {
+	st := newSSOTest(t, func(c *config.OIDCConfig) { c.AutoProvision = false })
+	if err := st.login(t, verifiedUser("nobody@example.com")); !errors.Is(err, ErrSSOUserNotProvisioned) {
+		t.Errorf("login = %v, want ErrSSOUserNotProvisioned", err)
+	}
+	if len(st.users.users) != 0 {
+		t.Error("user was provisioned with AutoProvision off")
+	}
+}
+
+func TestSSORefusesDeactivatedAccount(t *testing.T) {
+	st := newSSOTest(t, nil)
+	st.users.users["gone@example.com"] = &entities.User{ID: uuid.NewString(), Email: "gone@example.com", RoleID: "role-viewer"}
+	if err := st.login(t, verifiedUser("gone@example.com", "tracker-admins")); !errors.Is(err, ErrSSOAccountDisabled) {
+		t.Errorf("login = %v, want ErrSSOAccountDisabled", err)
+	}
+	if u := st.users.users["gone@example.com"]; u.IsActive || u.RoleID != "role-viewer" {
+		t.Errorf("deactivated user changed: %+v", u)
+	}
+}
+
+func TestSSOEmailVerified(t *testing.T) {
+	tests := []struct {
+		name    string
+		trust   bool
+		claims  map[string]any
+		wantErr bool
+	}{
+		{"verified", false, map[string]any{"email": "a@example.com", "email_verified": true}, false},
+		{"claim missing", false, map[string]any{"email": "a@example.com"}, true},
+		{"not verified", false, map[string]any{"email": "a@example.com", "email_verified": false}, true},
+		{"claim missing, issuer trusted", true, map[string]any{"email": "a@example.com"}, false},
+		{"email missing", true, map[string]any{"email_verified": true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSSOTest(t, func(c *config.OIDCConfig) { c.TrustEmail = tt.trust })
+			if err := st.login(t, tt.claims); (err != nil) != tt.wantErr {
+				t.Errorf("login = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSSORoleSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		sync     bool
+		groups   []string
+		wantRole string
+	}{
+		{"synced", true, []string{"tracker-editors"}, "role-editor"},
+		{"sync off keeps role", false, []string{"tracker-editors"}, "role-viewer"},
+		{"no mapped group keeps role", true, []string{"other"}, "role-viewer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSSOTest(t, func(c *config.OIDCConfig) { c.SyncRole = tt.sync })
+			st.users.users["x@example.com"] = &entities.User{ID: uuid.NewString(), Email: "x@example.com", RoleID: "role-viewer", IsActive: true}
+			if err := st.login(t, verifiedUser("x@example.com", tt.groups...)); err != nil {
+				t.Fatalf("login: %v", err)
+			}
+			if got := st.users.users["x@example.com"].RoleID; got != tt.wantRole {
+				t.Errorf("role = %s, want %s", got, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestSSORejectsBadState(t *testing.T) {
+	st := newSSOTest(t, nil)
+	ctx := context.Background()
+	authURL, err := st.uc.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, code := st.idp.authorize(t, authURL, verifiedUser("a@example.com"))
+	if _, _, _, err := st.uc.Callback(ctx, "forged", code); err == nil {
+		t.Error("callback with an unknown state succeeded")
+	}
+	if _, _, _, err := st.uc.Callback(ctx, state, code); err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	// state is single use
+	_, code = st.idp.authorize(t, authURL, verifiedUser("a@example.com"))
+	if _, _, _, err := st.uc.Callback(ctx, state, code); err == nil {
+		t.Error("callback reusing a state succeeded")
+	}
+}
+
+func TestSSORejectsWrongPKCEVerifier(t *testing.T) {
+	st := newSSOTest(t, nil)
+	ctx := context.Background()
+	authURL, err := st.uc.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, code := st.idp.authorize(t, authURL, verifiedUser("a@example.com"))
+	// swap the verifier kept for this state, as if the code was started by someone else
+	key := "sso:state:" + state
+	if err := st.rdb.Set(ctx, key, `{"verifier":"not-the-verifier","nonce":"`+nonceOf(t, authURL)+`"}`, time.Minute).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := st.uc.Callback(ctx, state, code); err == nil {
+		t.Error("callback with the wrong PKCE verifier succeeded")
+	}
+}
+
+func TestSSORejectsWrongNonce(t *testing.T) {
+	st := newSSOTest(t, nil)
+	ctx := context.Background()
+	authURL, err := st.uc.AuthURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, code := st.idp.authorize(t, authURL, verifiedUser("a@example.com"))
+	st.idp.mu.Lock()
+	g := st.idp.grants[code]
+	g.nonce = "replayed"
+	st.idp.grants[code] = g
+	st.idp.mu.Unlock()
+	if _, _, _, err := st.uc.Callback(ctx, state, code); err == nil || err.Error() != "invalid nonce" {
+		t.Errorf("callback = %v, want invalid nonce", err)
+	}
+}
+
+func nonceOf(t *testing.T, authURL string) string {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get("nonce")
+}
+
+func TestMapRole(t *testing.T) {
+	s := &ssoUsecase{cfg: config.OIDCConfig{
+		RoleMapping:  map[string]string{"admins": "admin", "editors": "editor", "viewers": "viewer"},
+		RolePriority: []string{"admin", "editor", "viewer"},
+	}}
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+	}{
+		{"none", nil, ""},
+		{"unmapped", []string{"other"}, ""},
+		{"single", []string{"editors"}, "editor"},
+		{"admin listed first", []string{"admins", "editors"}, "admin"},
+		{"admin listed last", []string{"viewers", "editors", "admins"}, "admin"},
+		{"editor over viewer", []string{"viewers", "other", "editors"}, "editor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.mapRole(tt.groups); got != tt.want {
+				t.Errorf("mapRole(%v) = %q, want %q", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
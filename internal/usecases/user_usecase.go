@@ -1,7 +1,7 @@
 package usecases
 
 import (
-	"errors"
+	"context"
 	"interview-tracker/internal/adapters/repositories"
 	"interview-tracker/internal/entities"
 	"interview-tracker/internal/models/user_models"
@@ -23,22 +23,22 @@ func NewUserUsecase(userRepo repositories.UserRepository, roleRepo repositories.
 	}
 }
 
-func (u *UserUsecase) CreateUser(item user_models.CreateUserRequest) (*entities.User, error) {
+func (u *UserUsecase) CreateUser(ctx context.Context, item user_models.CreateUserRequest) (*entities.User, error) {
 	// normalize
 	email := strings.TrimSpace(strings.ToLower(item.Email))
 
 	// ---- check duplicate email ----
-	if existing, _ := u.userRepo.GetByEmail(email); existing != nil {
-		return nil, errors.New("email already in use")
+	if existing, _ := u.userRepo.GetByEmail(ctx, email); existing != nil {
+		return nil, ErrEmailTaken
 	}
 
 	// ---- check exists roleId ----
-	ok, err := u.roleRepo.ExistsByID(item.RoleID)
+	ok, err := u.roleRepo.ExistsByID(ctx, item.RoleID)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("role not found")
+		return nil, ErrRoleNotFound
 	}
 	// ---- hash password ----
 	hashed, err := bcrypt.GenerateFromPassword([]byte(item.Password), bcrypt.DefaultCost)
@@ -58,13 +58,33 @@ func (u *UserUsecase) CreateUser(item user_models.CreateUserRequest) (*entities.
 		UpdatedAt: currentDtm,
 	}
 
-	return u.userRepo.Create(payload)
+	created, err := u.userRepo.Create(ctx, payload)
+	if err != nil {
+		return nil, dbErr(err, nil)
+	}
+	return created, nil
+}
+
+func (u *UserUsecase) GetUserById(ctx context.Context, id string) (*entities.User, error) {
+	user, err := u.userRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrUserNotFound)
+	}
+	return user, nil
 }
 
-func (u *UserUsecase) GetUserById(id string) (*entities.User, error) {
-	return u.userRepo.GetById(id)
+func (u *UserUsecase) GetRoleList(ctx context.Context) ([]*entities.Role, error) {
+	return u.roleRepo.GetList(ctx)
 }
 
-func (u *UserUsecase) GetRoleList() ([]*entities.Role, error) {
-	return u.roleRepo.GetList()
+// GetPermissionList returns active permissions with names in lang
+func (u *UserUsecase) GetPermissionList(ctx context.Context, lang string) ([]*entities.Permission, error) {
+	perms, err := u.roleRepo.GetPermissionList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		p.Localize(lang)
+	}
+	return perms, nil
 }
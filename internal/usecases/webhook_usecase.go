@@ -0,0 +1,282 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"interview-tracker/internal/adapters/repositories"
+	"interview-tracker/internal/config"
+	"interview-tracker/internal/entities"
+	"interview-tracker/internal/models/webhook_models"
+	"interview-tracker/internal/pkg/events"
+	"interview-tracker/internal/pkg/logs"
+	"interview-tracker/internal/pkg/metrics"
+	"interview-tracker/internal/pkg/token"
+	"interview-tracker/internal/pkg/webhook"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookSecretPrefix marks a webhook signing secret
+const WebhookSecretPrefix = "whsec_"
+
+// each dispatch run claims at most this many deliveries and sends up to dispatchWorkers at once
+const (
+	dispatchBatchSize = 100
+	dispatchWorkers   = 8
+)
+
+// retry delays double from retryBaseDelay (1m, 2m, 4m, ...) up to retryMaxDelay
+const (
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = 6 * time.Hour
+)
+
+type WebhookUsecase struct {
+	repo   repositories.WebhookRepository
+	sender *webhook.Sender
+	cfg    config.WebhookConfig
+}
+
+func NewWebhookUsecase(r repositories.WebhookRepository, cfg config.WebhookConfig) *WebhookUsecase {
+	return &WebhookUsecase{repo: r, sender: webhook.NewSender(cfg.Timeout, cfg.AllowedNetworks), cfg: cfg}
+}
+
+// Create registers a webhook. The signing secret is returned once and cannot be read back.
+func (uc *WebhookUsecase) Create(ctx context.Context, actor uuid.UUID, req webhook_models.CreateWebhookReq) (*webhook_models.CreateWebhookResp, error) {
+	secret, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	var txnDtm = time.Now()
+	w := &entities.Webhook{
+		URL:         req.URL,
+		Description: req.Description,
+		Secret:      WebhookSecretPrefix + secret,
+		Events:      pq.StringArray(req.Events),
+		IsActive:    true,
+		CreatedBy:   actor,
+		UpdatedBy:   actor,
+		CreatedAt:   txnDtm,
+		UpdatedAt:   txnDtm,
+	}
+	if err := uc.repo.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return &webhook_models.CreateWebhookResp{Webhook: w, Secret: w.Secret}, nil
+}
+
+func (uc *WebhookUsecase) List(ctx context.Context) ([]*entities.Webhook, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *WebhookUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entities.Webhook, error) {
+	w, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrWebhookNotFound)
+	}
+	return w, nil
+}
+
+func (uc *WebhookUsecase) Update(ctx context.Context, actor, id uuid.UUID, req webhook_models.UpdateWebhookReq) (*entities.Webhook, error) {
+	w, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, dbErr(err, ErrWebhookNotFound)
+	}
+	if req.URL != nil {
+		w.URL = *req.URL
+	}
+	if req.Description != nil {
+		w.Description = *req.Description
+	}
+	if req.Events != nil {
+		w.Events = pq.StringArray(*req.Events)
+	}
+	if req.IsActive != nil {
+		// re-enabling starts over: pending deliveries are sent again on the next dispatch
+		if *req.IsActive && !w.IsActive {
+			w.FailureCount = 0
+			w.DisabledAt = nil
+			w.DisabledReason = ""
+		}
+		w.IsActive = *req.IsActive
+	}
+	w.UpdatedBy = actor
+	w.UpdatedAt = time.Now()
+	if err := uc.repo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (uc *WebhookUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return dbErr(err, ErrWebhookNotFound)
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *WebhookUsecase) ListDeliveries(ctx context.Context, webhookID uuid.UUID, q webhook_models.ListDeliveriesQuery) ([]*entities.WebhookDelivery, int64, error) {
+	if _, err := uc.repo.GetByID(ctx, webhookID); err != nil {
+		return nil, 0, dbErr(err, ErrWebhookNotFound)
+	}
+	return uc.repo.ListDeliveries(ctx, webhookID, q.Status, q.Page, q.PageSize)
+}
+
+// Replay queues a new delivery with the same event and payload; the original stays in the log.
+// Deliveries of a disabled webhook wait until it is enabled again.
+func (uc *WebhookUsecase) Replay(ctx context.Context, webhookID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	d, err := uc.repo.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, dbErr(err, ErrDeliveryNotFound)
+	}
+	var txnDtm = time.Now()
+	cp := &entities.WebhookDelivery{
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Payload:       d.Payload,
+		Status:        entities.DeliveryPending,
+		NextAttemptAt: txnDtm,
+		ReplayedFrom:  &d.ID,
+		CreatedAt:     txnDtm,
+		UpdatedAt:     txnDtm,
+	}
+	if err := uc.repo.CreateDeliveries(ctx, []*entities.WebhookDelivery{cp}); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Publish queues one delivery per subscribed webhook; Dispatch sends them. It is the webhook
+// sink of the outbox relay: a repeated event does not queue a second delivery.
+func (uc *WebhookUsecase) Publish(ctx context.Context, ev events.Event) error {
+	hooks, err := uc.repo.ActiveFor(ctx, ev.Type)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	list := make([]*entities.WebhookDelivery, 0, len(hooks))
+	for _, w := range hooks {
+		list = append(list, &entities.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       ev.ID,
+			EventType:     ev.Type,
+			Payload:       payload,
+			Status:        entities.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	return uc.repo.CreateDeliveries(ctx, list)
+}
+
+// Dispatch sends the deliveries that are due. A claimed batch is finished even if the job
+// context ends, so attempts are always recorded; anything not claimed waits for the next run.
+func (uc *WebhookUsecase) Dispatch(ctx context.Context) error {
+	// the lease outlasts the slowest possible batch so a delivery is never sent twice at once
+	lease := uc.cfg.Timeout*time.Duration(dispatchBatchSize/dispatchWorkers+1) + time.Minute
+	due, err := uc.repo.ClaimDue(ctx, time.Now(), lease, dispatchBatchSize)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+	ids := make([]uuid.UUID, 0, len(due))
+	for _, d := range due {
+		ids = append(ids, d.WebhookID)
+	}
+	list, err := uc.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	hooks := make(map[uuid.UUID]*entities.Webhook, len(list))
+	for _, w := range list {
+		hooks[w.ID] = w
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	jobs := make(chan *entities.WebhookDelivery)
+	var wg sync.WaitGroup
+	for i := 0; i < dispatchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range jobs {
+				uc.attempt(ctx, hooks[d.WebhookID], d)
+			}
+		}()
+	}
+	for _, d := range due {
+		if hooks[d.WebhookID] != nil { // deleted after it was claimed
+			jobs <- d
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return nil
+}
+
+// attempt sends one delivery and records the outcome on the delivery and its webhook
+func (uc *WebhookUsecase) attempt(ctx context.Context, w *entities.Webhook, d *entities.WebhookDelivery) {
+	code, err := uc.sender.Send(ctx, w.URL, w.Secret, d.ID.String(), d.EventType, d.Payload)
+	now := time.Now()
+	d.Attempts++
+	d.UpdatedAt = now
+	d.LastStatusCode = nil
+	if code != 0 {
+		d.LastStatusCode = &code
+	}
+	ok := err == nil && code >= 200 && code < 300
+	result := entities.DeliverySucceeded
+	switch {
+	case ok:
+		d.Status = entities.DeliverySucceeded
+		d.DeliveredAt = &now
+		d.LastError = ""
+	case err != nil:
+		d.LastError = err.Error()
+	default:
+		d.LastError = fmt.Sprintf("unexpected status %d", code)
+	}
+	if !ok {
+		result = "retry"
+		if d.Attempts >= uc.cfg.MaxAttempts {
+			d.Status = entities.DeliveryFailed
+			result = entities.DeliveryFailed
+		} else {
+			d.NextAttemptAt = now.Add(retryDelay(d.Attempts))
+		}
+	}
+	metrics.WebhookDeliveries.WithLabelValues(d.EventType, result).Inc()
+
+	if err := uc.repo.SaveAttempt(ctx, d); err != nil {
+		logs.Logger.Errorf("webhooks| save delivery %s failed: %v", d.ID, err)
+	}
+	disabled, err := uc.repo.RecordResult(ctx, w.ID, ok, uc.cfg.DisableAfter,
+		fmt.Sprintf("%d consecutive failed deliveries; last error: %s", uc.cfg.DisableAfter, d.LastError))
+	if err != nil {
+		logs.Logger.Errorf("webhooks| record result for %s failed: %v", w.ID, err)
+	}
+	if disabled {
+		logs.Logger.Warnf("webhooks| disabled %s (%s) after %d consecutive failures", w.ID, w.URL, uc.cfg.DisableAfter)
+	}
+}
+
+// retryDelay is the wait after the n-th failed attempt, with ±10% jitter so
+// deliveries that failed together do not retry together
+func retryDelay(n int) time.Duration {
+	d := retryMaxDelay
+	if n-1 < 16 {
+		d = min(retryBaseDelay<<(n-1), retryMaxDelay)
+	}
+	return d + time.Duration((rand.Float64()*0.2-0.1)*float64(d))
+}
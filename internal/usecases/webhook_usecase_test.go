@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		n    int
+		base time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, retryMaxDelay}, // 512m is past the cap
+		{16, retryMaxDelay},
+		{17, retryMaxDelay},
+		{64, retryMaxDelay}, // would overflow the shift
+		{1000, retryMaxDelay},
+	}
+	for _, tt := range tests {
+		lo, hi := tt.base-tt.base/10, tt.base+tt.base/10
+		for range 200 {
+			if d := retryDelay(tt.n); d < lo || d > hi {
+				t.Fatalf("retryDelay(%d) = %v, want within [%v, %v]", tt.n, d, lo, hi)
+			}
+		}
+	}
+}
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE code = 'api_key_manage');
+DELETE FROM permissions WHERE code = 'api_key_manage';
+
+DROP TABLE IF EXISTS api_keys;
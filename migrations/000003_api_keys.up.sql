@@ -0,0 +1,41 @@
+-- API keys สำหรับ service-to-service (ATS sync, reporting scripts)
+CREATE TABLE IF NOT EXISTS api_keys (
+  id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  name          text NOT NULL,                      -- ชื่อ key เช่น ats-sync
+  prefix        text NOT NULL,                      -- ส่วนต้นของ key ใช้แสดงผล/ค้นหา
+  key_hash      text NOT NULL UNIQUE,               -- sha256 ของ key (ไม่เก็บ key จริง)
+  permissions   text[] NOT NULL DEFAULT '{}',       -- permission codes ที่ key นี้ใช้ได้
+  expires_at    timestamptz NOT NULL,
+  last_used_at  timestamptz NULL,
+  revoked_at    timestamptz NULL,
+  is_active     boolean NOT NULL DEFAULT true,
+  created_by    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  updated_by    uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  created_at    timestamptz NOT NULL DEFAULT now(),
+  updated_at    timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_by ON api_keys(created_by);
+
+COMMENT ON TABLE api_keys IS 'ตารางเก็บ API key สำหรับการเรียกใช้งานระหว่าง service';
+COMMENT ON COLUMN api_keys.id IS 'รหัส API key (UUID)';
+COMMENT ON COLUMN api_keys.name IS 'ชื่อ API key';
+COMMENT ON COLUMN api_keys.prefix IS 'ส่วนต้นของ key สำหรับแสดงผล';
+COMMENT ON COLUMN api_keys.key_hash IS 'ค่า hash (sha256) ของ key';
+COMMENT ON COLUMN api_keys.permissions IS 'รายการ permission code ที่ key ได้รับ';
+COMMENT ON COLUMN api_keys.expires_at IS 'วันและเวลาที่ key หมดอายุ';
+COMMENT ON COLUMN api_keys.last_used_at IS 'วันและเวลาที่ใช้งาน key ล่าสุด';
+COMMENT ON COLUMN api_keys.revoked_at IS 'วันและเวลาที่ยกเลิก key';
+COMMENT ON COLUMN api_keys.created_by IS 'UUID ของผู้ออก key (การกระทำผ่าน key จะนับเป็นของผู้ใช้นี้)';
+
+-- ============ SEED =============
+INSERT INTO permissions (code, name, description)
+VALUES ('api_key_manage', 'Manage API Keys', 'สามารถออกและยกเลิก API key ได้')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code = 'api_key_manage'
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+ALTER TABLE permissions
+  DROP COLUMN IF EXISTS name_th,
+  DROP COLUMN IF EXISTS name_en,
+  DROP COLUMN IF EXISTS description_th,
+  DROP COLUMN IF EXISTS description_en;
+
+ALTER TABLE card_statuses
+  DROP COLUMN IF EXISTS name_th,
+  DROP COLUMN IF EXISTS name_en,
+  DROP COLUMN IF EXISTS description_th,
+  DROP COLUMN IF EXISTS description_en;
@@ -0,0 +1,50 @@
+-- ชื่อและคำอธิบายแยกตามภาษา (th/en) ให้ API เลือกตาม Accept-Language
+ALTER TABLE card_statuses
+  ADD COLUMN IF NOT EXISTS name_th        text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS name_en        text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS description_th text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS description_en text NOT NULL DEFAULT '';
+
+ALTER TABLE permissions
+  ADD COLUMN IF NOT EXISTS name_th        text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS name_en        text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS description_th text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS description_en text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN card_statuses.name_th IS 'ชื่อสถานะภาษาไทย';
+COMMENT ON COLUMN card_statuses.name_en IS 'ชื่อสถานะภาษาอังกฤษ';
+COMMENT ON COLUMN card_statuses.description_th IS 'คำอธิบายสถานะภาษาไทย';
+COMMENT ON COLUMN card_statuses.description_en IS 'คำอธิบายสถานะภาษาอังกฤษ';
+COMMENT ON COLUMN permissions.name_th IS 'ชื่อ Permission ภาษาไทย';
+COMMENT ON COLUMN permissions.name_en IS 'ชื่อ Permission ภาษาอังกฤษ';
+COMMENT ON COLUMN permissions.description_th IS 'คำอธิบาย Permission ภาษาไทย';
+COMMENT ON COLUMN permissions.description_en IS 'คำอธิบาย Permission ภาษาอังกฤษ';
+
+-- ============ SEED =============
+-- เดิม name เป็นภาษาอังกฤษ และ description เป็นภาษาไทย
+UPDATE card_statuses SET name_en = name, description_th = description;
+UPDATE permissions SET name_en = name, description_th = description;
+
+UPDATE card_statuses AS s
+SET name_th = v.name_th, description_en = v.description_en
+FROM (VALUES
+  ('todo', 'รอดำเนินการ', 'Not started yet'),
+  ('in_progress', 'กำลังดำเนินการ', 'In progress'),
+  ('done', 'เสร็จสมบูรณ์', 'Completed')
+) AS v(status_code, name_th, description_en)
+WHERE s.status_code = v.status_code;
+
+UPDATE permissions AS p
+SET name_th = v.name_th, description_en = v.description_en
+FROM (VALUES
+  ('comment_add', 'เพิ่มความคิดเห็น', 'Can add comments'),
+  ('comment_edit', 'แก้ไขความคิดเห็น', 'Can edit comments'),
+  ('comment_view', 'ดูความคิดเห็น', 'Can view comments'),
+  ('comment_delete', 'ลบความคิดเห็น', 'Can delete comments'),
+  ('card_add', 'เพิ่มการ์ด', 'Can add cards'),
+  ('card_edit', 'แก้ไขการ์ด', 'Can edit cards'),
+  ('card_view', 'ดูการ์ด', 'Can view cards'),
+  ('card_delete', 'ลบการ์ด', 'Can delete cards'),
+  ('api_key_manage', 'จัดการ API Key', 'Can issue and revoke API keys')
+) AS v(code, name_th, description_en)
+WHERE p.code = v.code;
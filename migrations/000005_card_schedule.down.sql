@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS card_interviewers;
+
+DROP INDEX IF EXISTS idx_cards_position;
+DROP INDEX IF EXISTS idx_cards_schedule;
+
+ALTER TABLE cards
+  DROP CONSTRAINT IF EXISTS chk_cards_schedule,
+  DROP COLUMN IF EXISTS ends_at,
+  DROP COLUMN IF EXISTS time_zone,
+  DROP COLUMN IF EXISTS location,
+  DROP COLUMN IF EXISTS meeting_url,
+  DROP COLUMN IF EXISTS position;
@@ -0,0 +1,36 @@
+-- ช่วงเวลานัด (เริ่ม-สิ้นสุด), time zone, สถานที่/ลิงก์ประชุม และตำแหน่งงานของการ์ด
+ALTER TABLE cards
+  ADD COLUMN IF NOT EXISTS ends_at     timestamptz,
+  ADD COLUMN IF NOT EXISTS time_zone   text NOT NULL DEFAULT 'Asia/Bangkok',
+  ADD COLUMN IF NOT EXISTS location    text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS meeting_url text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS position    text NOT NULL DEFAULT '';
+
+-- การ์ดเดิมไม่มีเวลาสิ้นสุด ถือว่านัดละ 1 ชั่วโมง
+UPDATE cards SET ends_at = scheduled_at + interval '1 hour' WHERE ends_at IS NULL;
+ALTER TABLE cards ALTER COLUMN ends_at SET NOT NULL;
+ALTER TABLE cards ADD CONSTRAINT chk_cards_schedule CHECK (ends_at > scheduled_at);
+
+-- ผู้สัมภาษณ์ของแต่ละการ์ด (ใช้ตรวจการนัดซ้อน)
+CREATE TABLE IF NOT EXISTS card_interviewers (
+  card_id    uuid NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+  user_id    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (card_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cards_schedule ON cards(scheduled_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_cards_position ON cards(lower(position));
+CREATE INDEX IF NOT EXISTS idx_card_interviewers_user ON card_interviewers(user_id);
+
+COMMENT ON COLUMN cards.scheduled_at IS 'วันและเวลาเริ่มนัดสัมภาษณ์';
+COMMENT ON COLUMN cards.ends_at IS 'วันและเวลาสิ้นสุดนัดสัมภาษณ์';
+COMMENT ON COLUMN cards.time_zone IS 'IANA time zone ของนัด เช่น Asia/Bangkok';
+COMMENT ON COLUMN cards.location IS 'สถานที่สัมภาษณ์';
+COMMENT ON COLUMN cards.meeting_url IS 'ลิงก์ประชุมออนไลน์';
+COMMENT ON COLUMN cards.position IS 'ตำแหน่งงานที่สัมภาษณ์';
+
+COMMENT ON TABLE card_interviewers IS 'ตาราง Mapping ระหว่างการ์ดและผู้สัมภาษณ์';
+COMMENT ON COLUMN card_interviewers.card_id IS 'อ้างอิงไปยังตาราง cards';
+COMMENT ON COLUMN card_interviewers.user_id IS 'ผู้สัมภาษณ์ อ้างอิงไปยังตาราง users';
+COMMENT ON COLUMN card_interviewers.created_at IS 'วันและเวลาที่สร้าง record';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS calendar_feed_tokens;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,32 @@
+-- SEQUENCE ของ iCalendar: เพิ่มทุกครั้งที่เลื่อนนัด/ย้ายสถานที่/เก็บการ์ด เพื่อให้ปฏิทินของผู้ใช้อัปเดตตาม
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS sequence integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN cards.sequence IS 'ลำดับการแก้ไขของนัด (iCalendar SEQUENCE)';
+
+-- token ลับสำหรับ URL ปฏิทินรายผู้ใช้ (subscribe จาก Outlook/Google Calendar)
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+  id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id      uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name         text NOT NULL DEFAULT '',
+  prefix       text NOT NULL,
+  token_hash   text NOT NULL UNIQUE,       -- sha256 ของ token (ไม่เก็บ token จริง)
+  last_used_at timestamptz NULL,
+  revoked_at   timestamptz NULL,
+  is_active    boolean NOT NULL DEFAULT true,
+  created_at   timestamptz NOT NULL DEFAULT now(),
+  updated_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_feed_tokens_user ON calendar_feed_tokens(user_id);
+
+COMMENT ON TABLE calendar_feed_tokens IS 'ตารางเก็บ token ของ calendar feed (.ics) รายผู้ใช้';
+COMMENT ON COLUMN calendar_feed_tokens.id IS 'รหัส token (UUID)';
+COMMENT ON COLUMN calendar_feed_tokens.user_id IS 'เจ้าของ feed อ้างอิงไปยังตาราง users';
+COMMENT ON COLUMN calendar_feed_tokens.name IS 'ชื่อที่ผู้ใช้ตั้ง เช่น Outlook ที่ทำงาน';
+COMMENT ON COLUMN calendar_feed_tokens.prefix IS 'ส่วนต้นของ token ไว้แสดงให้ผู้ใช้จำได้';
+COMMENT ON COLUMN calendar_feed_tokens.token_hash IS 'sha256 ของ token';
+COMMENT ON COLUMN calendar_feed_tokens.last_used_at IS 'วันและเวลาที่ปฏิทินดึง feed ล่าสุด';
+COMMENT ON COLUMN calendar_feed_tokens.revoked_at IS 'วันและเวลาที่ยกเลิก token';
+COMMENT ON COLUMN calendar_feed_tokens.is_active IS 'สถานะ token';
+COMMENT ON COLUMN calendar_feed_tokens.created_at IS 'วันและเวลาที่สร้าง record';
+COMMENT ON COLUMN calendar_feed_tokens.updated_at IS 'วันและเวลาที่แก้ไข record ล่าสุด';
@@ -0,0 +1,16 @@
+-- บันทึกการแจ้งเตือนที่ส่งไปแล้ว กันไม่ให้ job ส่งซ้ำ (ทุก replica และทุกรอบ)
+CREATE TABLE IF NOT EXISTS card_notifications (
+  id       uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  card_id  uuid NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+  kind     text NOT NULL,          -- เช่น reminder_24h, stale_in_progress
+  ref_time timestamptz NOT NULL,   -- scheduled_at (reminder) หรือ updated_at (stale) ของการ์ดตอนส่ง
+  sent_at  timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (card_id, kind, ref_time)
+);
+
+COMMENT ON TABLE card_notifications IS 'ตารางเก็บประวัติการแจ้งเตือนของการ์ด';
+COMMENT ON COLUMN card_notifications.id IS 'รหัสการแจ้งเตือน (UUID)';
+COMMENT ON COLUMN card_notifications.card_id IS 'อ้างอิงไปยังตาราง cards';
+COMMENT ON COLUMN card_notifications.kind IS 'ประเภทการแจ้งเตือน เช่น reminder_24h, stale_in_progress';
+COMMENT ON COLUMN card_notifications.ref_time IS 'เวลาอ้างอิงของการ์ดตอนส่ง ถ้าเลื่อนนัดหรือมีการแก้ไขจะแจ้งใหม่ได้';
+COMMENT ON COLUMN card_notifications.sent_at IS 'วันและเวลาที่ส่งการแจ้งเตือน';
@@ -0,0 +1,6 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE code = 'webhook_manage');
+DELETE FROM permissions WHERE code = 'webhook_manage';
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
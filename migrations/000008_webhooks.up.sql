@@ -0,0 +1,78 @@
+-- ปลายทาง webhook ที่สมัครรับ event ของการ์ด/ความคิดเห็น
+CREATE TABLE IF NOT EXISTS webhooks (
+  id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  url             text NOT NULL,
+  description     text NOT NULL DEFAULT '',
+  secret          text NOT NULL,          -- ใช้เซ็น HMAC-SHA256 (ต้องเก็บค่าจริงเพื่อคำนวณลายเซ็น)
+  events          text[] NOT NULL,        -- เช่น {card.created, comment.created} หรือ {*}
+  failure_count   integer NOT NULL DEFAULT 0,
+  disabled_at     timestamptz NULL,
+  disabled_reason text NOT NULL DEFAULT '',
+  is_active       boolean NOT NULL DEFAULT true,
+  created_by      uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  updated_by      uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+
+-- ประวัติการส่ง webhook แต่ละครั้ง (ใช้ retry และ replay)
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  webhook_id       uuid NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  event_id         uuid NOT NULL,
+  event_type       text NOT NULL,
+  payload          jsonb NOT NULL,
+  status           text NOT NULL DEFAULT 'pending',  -- pending, succeeded, failed
+  attempts         integer NOT NULL DEFAULT 0,
+  next_attempt_at  timestamptz NOT NULL DEFAULT now(),
+  last_status_code integer NULL,
+  last_error       text NOT NULL DEFAULT '',
+  delivered_at     timestamptz NULL,
+  created_at       timestamptz NOT NULL DEFAULT now(),
+  updated_at       timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+
+COMMENT ON TABLE webhooks IS 'ตารางเก็บปลายทาง webhook';
+COMMENT ON COLUMN webhooks.id IS 'รหัส webhook (UUID)';
+COMMENT ON COLUMN webhooks.url IS 'URL ปลายทางที่รับ POST';
+COMMENT ON COLUMN webhooks.description IS 'คำอธิบาย เช่น Slack bot';
+COMMENT ON COLUMN webhooks.secret IS 'secret สำหรับเซ็น payload (HMAC-SHA256)';
+COMMENT ON COLUMN webhooks.events IS 'ประเภท event ที่สมัครรับ (* = ทั้งหมด)';
+COMMENT ON COLUMN webhooks.failure_count IS 'จำนวนครั้งที่ส่งไม่สำเร็จติดต่อกัน';
+COMMENT ON COLUMN webhooks.disabled_at IS 'วันและเวลาที่ระบบปิด webhook อัตโนมัติ';
+COMMENT ON COLUMN webhooks.disabled_reason IS 'เหตุผลที่ปิด webhook';
+COMMENT ON COLUMN webhooks.is_active IS 'สถานะ webhook';
+COMMENT ON COLUMN webhooks.created_by IS 'ผู้สร้าง';
+COMMENT ON COLUMN webhooks.updated_by IS 'ผู้แก้ไขล่าสุด';
+COMMENT ON COLUMN webhooks.created_at IS 'วันและเวลาที่สร้าง record';
+COMMENT ON COLUMN webhooks.updated_at IS 'วันและเวลาที่แก้ไข record ล่าสุด';
+
+COMMENT ON TABLE webhook_deliveries IS 'ตารางเก็บประวัติการส่ง webhook';
+COMMENT ON COLUMN webhook_deliveries.id IS 'รหัสการส่ง (UUID)';
+COMMENT ON COLUMN webhook_deliveries.webhook_id IS 'อ้างอิงไปยังตาราง webhooks';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'รหัส event (ซ้ำได้เมื่อ replay)';
+COMMENT ON COLUMN webhook_deliveries.event_type IS 'ประเภท event เช่น card.created';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'JSON ที่ส่งไป';
+COMMENT ON COLUMN webhook_deliveries.status IS 'สถานะ pending, succeeded, failed';
+COMMENT ON COLUMN webhook_deliveries.attempts IS 'จำนวนครั้งที่พยายามส่ง';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'เวลาที่จะลองส่งครั้งถัดไป';
+COMMENT ON COLUMN webhook_deliveries.last_status_code IS 'HTTP status ล่าสุดจากปลายทาง';
+COMMENT ON COLUMN webhook_deliveries.last_error IS 'ข้อผิดพลาดล่าสุด';
+COMMENT ON COLUMN webhook_deliveries.delivered_at IS 'วันและเวลาที่ส่งสำเร็จ';
+COMMENT ON COLUMN webhook_deliveries.created_at IS 'วันและเวลาที่สร้าง record';
+COMMENT ON COLUMN webhook_deliveries.updated_at IS 'วันและเวลาที่แก้ไข record ล่าสุด';
+
+-- ============ SEED =============
+INSERT INTO permissions (code, name, description, name_th, name_en, description_th, description_en)
+VALUES ('webhook_manage', 'Manage Webhooks', 'สามารถจัดการ webhook ได้', 'จัดการ Webhook', 'Manage Webhooks', 'สามารถจัดการ webhook ได้', 'Can manage webhooks')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code = 'webhook_manage'
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uq_webhook_deliveries_event;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS replayed_from;
+
+DROP TABLE IF EXISTS outbox;
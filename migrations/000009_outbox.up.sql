@@ -0,0 +1,38 @@
+-- outbox ของ event การ์ด/ความคิดเห็น เขียนใน transaction เดียวกับการแก้ไขข้อมูล แล้ว relay ส่งต่อภายหลัง
+CREATE TABLE IF NOT EXISTS outbox (
+  id              bigserial PRIMARY KEY,            -- ลำดับการ commit ของแต่ละการ์ด
+  event_id        uuid NOT NULL UNIQUE,             -- idempotency key ที่ส่งให้ปลายทาง
+  card_id         uuid NOT NULL,
+  event_type      text NOT NULL,
+  actor_id        uuid NULL,
+  payload         jsonb NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  attempts        integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamptz NOT NULL DEFAULT now(),
+  last_error      text NOT NULL DEFAULT '',
+  sinks_done      text[] NOT NULL DEFAULT '{}',     -- sink ที่ส่งสำเร็จแล้ว ไม่ส่งซ้ำตอน retry
+  published_at    timestamptz NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_card_unpublished ON outbox(card_id, id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at ON outbox(published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE outbox IS 'ตาราง outbox ของ domain event รอส่งไปยัง webhook, Redis Stream และ log';
+COMMENT ON COLUMN outbox.id IS 'ลำดับของ event (ใช้เรียงลำดับต่อการ์ด)';
+COMMENT ON COLUMN outbox.event_id IS 'รหัส event (UUID) ใช้เป็น idempotency key';
+COMMENT ON COLUMN outbox.card_id IS 'การ์ดที่เกี่ยวข้อง';
+COMMENT ON COLUMN outbox.event_type IS 'ประเภท event เช่น card.created';
+COMMENT ON COLUMN outbox.actor_id IS 'ผู้ที่ทำให้เกิด event';
+COMMENT ON COLUMN outbox.payload IS 'ข้อมูลของ event (data)';
+COMMENT ON COLUMN outbox.created_at IS 'วันและเวลาที่เกิด event';
+COMMENT ON COLUMN outbox.attempts IS 'จำนวนครั้งที่พยายามส่งไม่สำเร็จ';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'เวลาที่จะลองส่งครั้งถัดไป';
+COMMENT ON COLUMN outbox.last_error IS 'ข้อผิดพลาดล่าสุด';
+COMMENT ON COLUMN outbox.sinks_done IS 'ปลายทางที่ส่งสำเร็จแล้ว';
+COMMENT ON COLUMN outbox.published_at IS 'วันและเวลาที่ส่งครบทุกปลายทาง';
+
+-- การส่ง webhook ซ้ำจาก outbox (at-least-once) ต้องไม่สร้าง delivery ซ้ำ ยกเว้นการ replay
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS replayed_from uuid NULL REFERENCES webhook_deliveries(id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id) WHERE replayed_from IS NULL;
+COMMENT ON COLUMN webhook_deliveries.replayed_from IS 'การส่งต้นฉบับ กรณีเป็นการ replay';
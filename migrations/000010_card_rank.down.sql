@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_cards_board;
+ALTER TABLE cards DROP COLUMN IF EXISTS rank;
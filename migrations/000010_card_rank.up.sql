@@ -0,0 +1,11 @@
+-- ลำดับการ์ดภายในคอลัมน์ของ board (fractional indexing: เรียงตาม byte จึงใช้ COLLATE "C")
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS rank text COLLATE "C" NOT NULL DEFAULT '';
+
+-- การ์ดเดิมเรียงตามเวลานัด รูปแบบเดียวกับ rank.Spread ใน Go
+UPDATE cards c SET rank = lpad(to_hex(o.rn), 8, '0') || 'i'
+FROM (SELECT id, row_number() OVER (PARTITION BY status_code ORDER BY scheduled_at, id) AS rn FROM cards) o
+WHERE c.id = o.id;
+
+CREATE INDEX IF NOT EXISTS idx_cards_board ON cards(status_code, rank, id) WHERE is_active = TRUE;
+
+COMMENT ON COLUMN cards.rank IS 'ลำดับของการ์ดในคอลัมน์สถานะ (fractional index, ค่าน้อยอยู่บน)';
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS card_comment_reactions;
+DROP TABLE IF EXISTS card_comment_mentions;
+DROP TABLE IF EXISTS card_comment_edits;
+
+DROP INDEX IF EXISTS idx_comments_parent;
+DROP INDEX IF EXISTS idx_comments_card_created;
+ALTER TABLE card_comments
+  DROP COLUMN IF EXISTS edited_at,
+  DROP COLUMN IF EXISTS edited,
+  DROP COLUMN IF EXISTS parent_id;
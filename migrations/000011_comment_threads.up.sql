@@ -0,0 +1,60 @@
+-- เธรดตอบกลับ (ลึกหนึ่งชั้น: parent_id ชี้ไปที่ความคิดเห็นหลักเสมอ) และสถานะการแก้ไข
+ALTER TABLE card_comments
+  ADD COLUMN IF NOT EXISTS parent_id uuid NULL REFERENCES card_comments(id) ON DELETE CASCADE,
+  ADD COLUMN IF NOT EXISTS edited    boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS edited_at timestamptz NULL;
+
+CREATE INDEX IF NOT EXISTS idx_comments_card_created ON card_comments(card_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_parent ON card_comments(parent_id, created_at) WHERE parent_id IS NOT NULL;
+
+COMMENT ON COLUMN card_comments.parent_id IS 'ความคิดเห็นหลักที่ตอบกลับ (NULL = ความคิดเห็นหลัก)';
+COMMENT ON COLUMN card_comments.edited IS 'เคยถูกแก้ไขหรือไม่';
+COMMENT ON COLUMN card_comments.edited_at IS 'วันและเวลาที่แก้ไขเนื้อหาล่าสุด';
+
+-- ประวัติการแก้ไข: เก็บเนื้อหาก่อนแก้ทุกครั้ง
+CREATE TABLE IF NOT EXISTS card_comment_edits (
+  id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  comment_id uuid NOT NULL REFERENCES card_comments(id) ON DELETE CASCADE,
+  content    text NOT NULL,
+  edited_by  uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  edited_at  timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_comment_edits_comment ON card_comment_edits(comment_id, edited_at);
+
+COMMENT ON TABLE card_comment_edits IS 'ตารางเก็บประวัติการแก้ไขความคิดเห็น';
+COMMENT ON COLUMN card_comment_edits.id IS 'รหัสประวัติการแก้ไข (UUID)';
+COMMENT ON COLUMN card_comment_edits.comment_id IS 'อ้างอิงไปยังตาราง card_comments';
+COMMENT ON COLUMN card_comment_edits.content IS 'เนื้อหาก่อนการแก้ไขครั้งนี้';
+COMMENT ON COLUMN card_comment_edits.edited_by IS 'ผู้แก้ไข';
+COMMENT ON COLUMN card_comment_edits.edited_at IS 'วันและเวลาที่แก้ไข';
+
+-- ผู้ใช้ที่ถูก @mention ในความคิดเห็น
+CREATE TABLE IF NOT EXISTS card_comment_mentions (
+  comment_id uuid NOT NULL REFERENCES card_comments(id) ON DELETE CASCADE,
+  user_id    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  handle     text NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (comment_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user ON card_comment_mentions(user_id);
+
+COMMENT ON TABLE card_comment_mentions IS 'ตาราง Mapping ระหว่างความคิดเห็นและผู้ใช้ที่ถูกกล่าวถึง';
+COMMENT ON COLUMN card_comment_mentions.comment_id IS 'อ้างอิงไปยังตาราง card_comments';
+COMMENT ON COLUMN card_comment_mentions.user_id IS 'ผู้ใช้ที่ถูกกล่าวถึง อ้างอิงไปยังตาราง users';
+COMMENT ON COLUMN card_comment_mentions.handle IS 'ข้อความที่ใช้ mention เช่น somchai หรือ somchai@example.com';
+COMMENT ON COLUMN card_comment_mentions.created_at IS 'วันและเวลาที่ถูกกล่าวถึง';
+
+-- emoji reaction ต่อความคิดเห็น (ผู้ใช้หนึ่งคนใส่ emoji เดียวกันได้ครั้งเดียว)
+CREATE TABLE IF NOT EXISTS card_comment_reactions (
+  comment_id uuid NOT NULL REFERENCES card_comments(id) ON DELETE CASCADE,
+  user_id    uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  emoji      text NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (comment_id, emoji, user_id)
+);
+
+COMMENT ON TABLE card_comment_reactions IS 'ตารางเก็บ emoji reaction ของความคิดเห็น';
+COMMENT ON COLUMN card_comment_reactions.comment_id IS 'อ้างอิงไปยังตาราง card_comments';
+COMMENT ON COLUMN card_comment_reactions.user_id IS 'ผู้ใช้ที่ react อ้างอิงไปยังตาราง users';
+COMMENT ON COLUMN card_comment_reactions.emoji IS 'emoji เช่น 👍';
+COMMENT ON COLUMN card_comment_reactions.created_at IS 'วันและเวลาที่ react';
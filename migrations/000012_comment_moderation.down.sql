@@ -0,0 +1,8 @@
+ALTER TABLE card_history_logs
+  DROP COLUMN IF EXISTS comment_id,
+  DROP COLUMN IF EXISTS action;
+
+ALTER TABLE card_comments
+  DROP COLUMN IF EXISTS delete_reason,
+  DROP COLUMN IF EXISTS deleted_by,
+  DROP COLUMN IF EXISTS deleted_at;
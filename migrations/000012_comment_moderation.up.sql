@@ -0,0 +1,18 @@
+-- ลบความคิดเห็นแบบ soft delete (is_active = false) เก็บผู้ลบ เวลา และเหตุผลไว้ให้กู้คืนได้
+ALTER TABLE card_comments
+  ADD COLUMN IF NOT EXISTS deleted_at    timestamptz NULL,
+  ADD COLUMN IF NOT EXISTS deleted_by    uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS delete_reason text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN card_comments.is_active IS 'false = ถูกลบ (soft delete) ยังกู้คืนได้';
+COMMENT ON COLUMN card_comments.deleted_at IS 'วันและเวลาที่ถูกลบ';
+COMMENT ON COLUMN card_comments.deleted_by IS 'ผู้ลบ (ผู้เขียนเอง หรือผู้ดูแลที่มีสิทธิ์ comment_delete)';
+COMMENT ON COLUMN card_comments.delete_reason IS 'เหตุผลที่ผู้ดูแลลบความคิดเห็น';
+
+-- ประวัติการ์ดบันทึกประเภทของกิจกรรม และความคิดเห็นที่เกี่ยวข้อง
+ALTER TABLE card_history_logs
+  ADD COLUMN IF NOT EXISTS action     text NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS comment_id uuid NULL REFERENCES card_comments(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN card_history_logs.action IS 'กิจกรรม เช่น card_created, status_changed, comment_deleted (ว่าง = ข้อมูลก่อน migration 000012)';
+COMMENT ON COLUMN card_history_logs.comment_id IS 'ความคิดเห็นที่เกี่ยวข้อง (กิจกรรมของความคิดเห็น)';
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE code IN ('attachment_view', 'attachment_add', 'attachment_delete'));
+DELETE FROM permissions WHERE code IN ('attachment_view', 'attachment_add', 'attachment_delete');
+
+DROP TABLE IF EXISTS card_attachments;
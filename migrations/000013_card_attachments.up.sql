@@ -0,0 +1,54 @@
+-- ไฟล์แนบของการ์ด (เรซูเม่ พอร์ตโฟลิโอ งาน take-home) เนื้อไฟล์อยู่ใน storage ตารางนี้เก็บแค่ข้อมูลไฟล์
+CREATE TABLE IF NOT EXISTS card_attachments (
+  id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  card_id      uuid NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+  storage_key  text NOT NULL UNIQUE,
+  filename     text NOT NULL,
+  content_type text NOT NULL,
+  size_bytes   bigint NOT NULL,
+  checksum     text NOT NULL,          -- SHA-256 (hex) ของเนื้อไฟล์
+  uploaded_by  uuid NULL REFERENCES users(id) ON DELETE SET NULL,
+  created_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_attachments_card ON card_attachments(card_id, created_at DESC);
+
+COMMENT ON TABLE card_attachments IS 'ตารางเก็บไฟล์แนบของการ์ด';
+COMMENT ON COLUMN card_attachments.id IS 'รหัสไฟล์แนบ (UUID)';
+COMMENT ON COLUMN card_attachments.card_id IS 'อ้างอิงไปยังตาราง cards';
+COMMENT ON COLUMN card_attachments.storage_key IS 'key ของไฟล์ใน storage (local หรือ S3)';
+COMMENT ON COLUMN card_attachments.filename IS 'ชื่อไฟล์ตามที่อัปโหลด ใช้ตอนดาวน์โหลด';
+COMMENT ON COLUMN card_attachments.content_type IS 'MIME type ที่ตรวจจากเนื้อไฟล์';
+COMMENT ON COLUMN card_attachments.size_bytes IS 'ขนาดไฟล์ (ไบต์)';
+COMMENT ON COLUMN card_attachments.checksum IS 'SHA-256 (hex) ของเนื้อไฟล์';
+COMMENT ON COLUMN card_attachments.uploaded_by IS 'ผู้อัปโหลด';
+COMMENT ON COLUMN card_attachments.created_at IS 'วันและเวลาที่อัปโหลด';
+
+-- ============ SEED =============
+INSERT INTO permissions (code, name, description, name_th, name_en, description_th, description_en)
+VALUES
+  ('attachment_view', 'View Attachments', 'สามารถดูและดาวน์โหลดไฟล์แนบได้', 'ดูไฟล์แนบ', 'View Attachments', 'สามารถดูและดาวน์โหลดไฟล์แนบได้', 'Can list and download attachments'),
+  ('attachment_add', 'Add Attachments', 'สามารถแนบไฟล์และลบไฟล์ที่ตนเองแนบได้', 'แนบไฟล์', 'Add Attachments', 'สามารถแนบไฟล์และลบไฟล์ที่ตนเองแนบได้', 'Can attach files and delete their own'),
+  ('attachment_delete', 'Delete Attachments', 'สามารถลบไฟล์แนบของผู้อื่นได้', 'ลบไฟล์แนบ', 'Delete Attachments', 'สามารถลบไฟล์แนบของผู้อื่นได้', 'Can delete anyone''s attachments')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code IN ('attachment_view', 'attachment_add', 'attachment_delete')
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code IN ('attachment_view', 'attachment_add')
+WHERE r.code = 'editor'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.code = 'attachment_view'
+WHERE r.code = 'viewer'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_users_revoke_calendar_feeds ON users;
+DROP FUNCTION IF EXISTS revoke_calendar_feeds_of_inactive_user();
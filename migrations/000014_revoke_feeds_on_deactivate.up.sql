@@ -0,0 +1,26 @@
+-- ปิดการใช้งาน user แล้ว calendar feed ของ user นั้นถูกยกเลิกทันที
+-- ทำเป็น trigger เพื่อให้ครอบคลุมทุกช่องทาง รวมถึงการแก้ในฐานข้อมูลตรง ๆ
+CREATE OR REPLACE FUNCTION revoke_calendar_feeds_of_inactive_user() RETURNS trigger AS $$
+BEGIN
+  UPDATE calendar_feed_tokens
+     SET is_active = false, revoked_at = now(), updated_at = now()
+   WHERE user_id = NEW.id AND revoked_at IS NULL;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_users_revoke_calendar_feeds ON users;
+CREATE TRIGGER trg_users_revoke_calendar_feeds
+  AFTER UPDATE OF is_active ON users
+  FOR EACH ROW
+  WHEN (OLD.is_active AND NOT NEW.is_active)
+  EXECUTE FUNCTION revoke_calendar_feeds_of_inactive_user();
+
+-- feed ของ user ที่ถูกปิดไปก่อนหน้านี้
+UPDATE calendar_feed_tokens
+   SET is_active = false, revoked_at = now(), updated_at = now()
+ WHERE revoked_at IS NULL
+   AND user_id IN (SELECT id FROM users WHERE is_active = false);
+
+COMMENT ON FUNCTION revoke_calendar_feeds_of_inactive_user() IS 'ยกเลิก calendar feed ทั้งหมดของ user ที่ถูกปิดการใช้งาน';
+COMMENT ON TRIGGER trg_users_revoke_calendar_feeds ON users IS 'เรียก revoke_calendar_feeds_of_inactive_user เมื่อ users.is_active เปลี่ยนจาก true เป็น false';